@@ -0,0 +1,212 @@
+package main
+
+import (
+    "github.com/eujoy/data-dict/internal/infra/db/postgres"
+    "github.com/eujoy/data-dict/internal/model/domain"
+    postgresRepository "github.com/eujoy/data-dict/internal/repository/postgres"
+    "github.com/eujoy/data-dict/internal/service/classifier"
+    "github.com/eujoy/data-dict/internal/service/decorator"
+    "github.com/eujoy/data-dict/pkg"
+    "github.com/urfave/cli/v2"
+)
+
+// databaseOptions describes the connection details of the database shared by all the commands.
+type databaseOptions struct {
+    host   string
+    port   int
+    name   string
+    user   string
+    pass   string
+    schema string
+}
+
+// fetchOptions describes the optional details to retrieve along with the schema of the database.
+type fetchOptions struct {
+    inferRelationships bool
+    showPartitions     bool
+    routineBodies      bool
+    focusTables        []string
+    focusDepth         int
+    focusKeysOnly      bool
+    profile            bool
+    classify           bool
+    tagRules           []decorator.TagRule
+    subjectAreas       []decorator.SubjectArea
+    classifier         *classifier.Service
+    sample             decorator.SampleOptions
+}
+
+// databaseFlags returns the command line flags required for connecting to the database.
+func databaseFlags(opts *databaseOptions) []cli.Flag {
+    return []cli.Flag{
+        &cli.StringFlag{
+            Name:        "dbHost",
+            Aliases:     []string{"l", "L"},
+            Usage:       "Define the host of the database.",
+            Required:    true,
+            Destination: &opts.host,
+        },
+        &cli.IntFlag{
+            Name:        "dbPort",
+            Aliases:     []string{"p", "P"},
+            Usage:       "Define the port of the database.",
+            Required:    true,
+            Destination: &opts.port,
+        },
+        &cli.StringFlag{
+            Name:        "dbName",
+            Aliases:     []string{"n", "N"},
+            Usage:       "Define the name of the database.",
+            Required:    true,
+            Destination: &opts.name,
+        },
+        &cli.StringFlag{
+            Name:        "dbUser",
+            Aliases:     []string{"u", "U"},
+            Usage:       "Define the user of the database.",
+            Required:    true,
+            Destination: &opts.user,
+        },
+        &cli.StringFlag{
+            Name:        "dbPass",
+            Aliases:     []string{"s", "S"},
+            Usage:       "Define the password of the database.",
+            Required:    true,
+            Destination: &opts.pass,
+        },
+        &cli.StringFlag{
+            Name:        "dbSchema",
+            Aliases:     []string{"c", "C"},
+            Usage:       "Define the schema of the database.",
+            Required:    false,
+            Value:       "public",
+            Destination: &opts.schema,
+        },
+    }
+}
+
+// filterFlags returns the command line flags for including or excluding tables and columns.
+func filterFlags() []cli.Flag {
+    return []cli.Flag{
+        &cli.StringSliceFlag{
+            Name:     "include",
+            Usage:    "Include only the tables matching the pattern (glob, or regular expression when prefixed with 're:'). Can be provided multiple times.",
+            Required: false,
+        },
+        &cli.StringSliceFlag{
+            Name:     "exclude",
+            Usage:    "Exclude the tables matching the pattern (glob, or regular expression when prefixed with 're:'). Can be provided multiple times.",
+            Required: false,
+        },
+        &cli.StringSliceFlag{
+            Name:     "includeColumn",
+            Usage:    "Include only the columns (or table.column) matching the pattern (glob, or regular expression when prefixed with 're:'). Can be provided multiple times.",
+            Required: false,
+        },
+        &cli.StringSliceFlag{
+            Name:     "excludeColumn",
+            Usage:    "Exclude the columns (or table.column) matching the pattern (glob, or regular expression when prefixed with 're:'). Can be provided multiple times.",
+            Required: false,
+        },
+    }
+}
+
+// newFilter compiles the include and exclude patterns provided to the command.
+func newFilter(c *cli.Context) (postgresRepository.Filter, *pkg.Error) {
+    var filter postgresRepository.Filter
+    for _, p := range []struct {
+        flagName string
+        patterns *pkg.Patterns
+    }{
+        {flagName: "include", patterns: &filter.IncludeTables},
+        {flagName: "exclude", patterns: &filter.ExcludeTables},
+        {flagName: "includeColumn", patterns: &filter.IncludeColumns},
+        {flagName: "excludeColumn", patterns: &filter.ExcludeColumns},
+    } {
+        patterns, err := pkg.NewPatterns(c.StringSlice(p.flagName))
+        if err != nil {
+            return postgresRepository.Filter{}, err
+        }
+
+        *p.patterns = patterns
+    }
+
+    return filter, nil
+}
+
+// newRepository connects to the database and creates the repository to retrieve the schema details from.
+func newRepository(opts databaseOptions, filter postgresRepository.Filter) (*postgresRepository.Repo, *pkg.Error) {
+    dbConn, err := postgres.New(opts.host, opts.port, opts.name, opts.user, opts.pass)
+    if err != nil {
+        return nil, err
+    }
+
+    session := dbConn.NewSession(nil)
+    return postgresRepository.New(opts.name, opts.schema, session).WithFilter(filter), nil
+}
+
+// fetchTemplateValues retrieves all the schema details from the repository and prepares the template values.
+func fetchTemplateValues(repo *postgresRepository.Repo, opts databaseOptions, fetchOpts fetchOptions) (domain.TemplateValues, *pkg.Error) {
+    decoratorService := decorator.New(repo, opts.name)
+
+    decoratorService.GetTables()
+
+    if !fetchOpts.showPartitions {
+        decoratorService.HidePartitions()
+    }
+
+    decoratorService.GetColumnsOfAllTables().
+        GetPrimaryKeyOfAllTables().
+        GetForeignKeyOfAllTables().
+        GetGenericConstraintsOfAllTables().
+        GetIndexesOfAllTables().
+        GetTriggersOfAllTables().
+        GetSecurityOfAllTables().
+        GetViews().
+        GetInheritance().
+        GetRoutines(fetchOpts.routineBodies).
+        GetSequences().
+        GetDomains().
+        GetCompositeTypes().
+        TagTablesAndColumns(fetchOpts.tagRules)
+
+    if fetchOpts.inferRelationships {
+        decoratorService.InferRelationships()
+    }
+
+    if repo.FiltersTables() {
+        decoratorService.KeepRelatedObjectsOnly()
+    }
+
+    if len(fetchOpts.focusTables) > 0 {
+        decoratorService.FocusOnTables(fetchOpts.focusTables, fetchOpts.focusDepth, fetchOpts.focusKeysOnly)
+    }
+
+    if len(fetchOpts.subjectAreas) > 0 {
+        decoratorService.GroupIntoSubjectAreas(fetchOpts.subjectAreas)
+    }
+
+    if fetchOpts.classify {
+        decoratorService.ClassifyColumnsByName(fetchOpts.classifier)
+    }
+
+    if fetchOpts.profile {
+        decoratorService.GetStatisticsOfAllTables()
+    }
+
+    if fetchOpts.sample.Limit > 0 {
+        decoratorService.GetSampleValuesOfAllTables(fetchOpts.sample)
+
+        if fetchOpts.classify {
+            decoratorService.ClassifyColumnsBySampleValues(fetchOpts.classifier, fetchOpts.sample)
+        }
+    }
+
+    templateValues, err := decoratorService.PrepareTemplateValues()
+    if err != nil {
+        return domain.TemplateValues{}, err
+    }
+
+    templateValues.SchemaName = opts.schema
+    return templateValues, nil
+}
@@ -5,19 +5,26 @@ import (
     "io/ioutil"
     "os"
     "path/filepath"
+    "regexp"
+    "time"
 
     "github.com/eujoy/data-dict/internal/config"
-    "github.com/eujoy/data-dict/internal/infra/db/postgres"
-    postgresRepository "github.com/eujoy/data-dict/internal/repository/postgres"
+    "github.com/eujoy/data-dict/internal/service/classifier"
     "github.com/eujoy/data-dict/internal/service/decorator"
+    "github.com/eujoy/data-dict/internal/service/integrity"
+    "github.com/eujoy/data-dict/internal/service/lint"
     "github.com/eujoy/data-dict/internal/service/template"
     "github.com/eujoy/data-dict/pkg"
-    "github.com/gocraft/dbr/v2"
     "github.com/urfave/cli/v2"
 )
 
 const (
     configurationFileName = "configuration.yaml"
+
+    defaultSamplePercentage = 10
+    defaultSampleTimeout    = 2 * time.Second
+    defaultOrphanLimit      = 1000
+    defaultCheckTimeout     = 30 * time.Second
 )
 
 func main() {
@@ -29,11 +36,13 @@ func main() {
     var app = cli.NewApp()
     info(app, cfg)
 
-    var output, outputType, outputFile string
-    var dbHost, dbName, dbUser, dbPass, dbSchema string
-    var dbPort int
+    var output, outputType, outputFile, diagramDetail string
+    var lintFormat, migrationsDir string
+    var orphanLimit int
+    var checkTimeout time.Duration
+    var dbOpts databaseOptions
+    var fetchOpts fetchOptions
 
-    var dbConn *dbr.Connection
     tmplEngine := template.New()
 
     app.Commands = []*cli.Command{
@@ -41,11 +50,11 @@ func main() {
             Name:    "generate",
             Aliases: []string{"gen"},
             Usage:   "Generate the data dictionary / model representation from the database.",
-            Flags: []cli.Flag{
+            Flags: append([]cli.Flag{
                 &cli.StringFlag{
                     Name:        "outputType",
                     Aliases:     []string{"t", "T"},
-                    Usage:       "Define the output type. Allowed values: ['er', 'html', 'md', 'mermaid']",
+                    Usage:       "Define the output type. Allowed values: ['access', 'er', 'html', 'json', 'md', 'mermaid', 'register', 'site']",
                     Required:    false,
                     Value:       "mermaid",
                     Destination: &outputType,
@@ -61,77 +70,126 @@ func main() {
                 &cli.StringFlag{
                     Name:        "outputFile",
                     Aliases:     []string{"f", "F"},
-                    Usage:       "Define the output file to publish the data to. This value will be used only in combination when [--output file] is provided.",
+                    Usage:       "Define the output file (or directory for [--outputType site]) to publish the data to. This value will be used only in combination when [--output file] is provided.",
                     Required:    false,
                     Value:       "std",
                     Destination: &outputFile,
                 },
                 &cli.StringFlag{
-                    Name:        "dbHost",
-                    Aliases:     []string{"l", "L"},
-                    Usage:       "Define the host of the database.",
-                    Required:    true,
-                    Destination: &dbHost,
+                    Name:        "detail",
+                    Usage:       "Define the level of detail of the tables in the diagrams. Allowed values: ['full', 'keys' (PK, FK and UQ columns only), 'names' (table names only)]",
+                    Required:    false,
+                    Value:       "full",
+                    Destination: &diagramDetail,
+                },
+                &cli.BoolFlag{
+                    Name:        "inferRelationships",
+                    Usage:       "Infer relationships from the naming conventions of the columns (i.e. 'customer_id' to 'customers.id') for the columns that are not part of a foreign key.",
+                    Required:    false,
+                    Value:       false,
+                    Destination: &fetchOpts.inferRelationships,
+                },
+                &cli.BoolFlag{
+                    Name:        "showPartitions",
+                    Usage:       "Document the partitions of the partitioned tables as separate tables. By default, the partitions are only listed under their partitioned table.",
+                    Required:    false,
+                    Value:       false,
+                    Destination: &fetchOpts.showPartitions,
+                },
+                &cli.BoolFlag{
+                    Name:        "routineBodies",
+                    Usage:       "Include the bodies of the functions and procedures in the documentation.",
+                    Required:    false,
+                    Value:       false,
+                    Destination: &fetchOpts.routineBodies,
+                },
+                &cli.StringSliceFlag{
+                    Name:     "focus",
+                    Usage:    "Keep only the provided table, and the tables within [--depth] relationship hops from it. Can be provided multiple times.",
+                    Required: false,
                 },
                 &cli.IntFlag{
-                    Name:        "dbPort",
-                    Aliases:     []string{"p", "P"},
-                    Usage:       "Define the port of the database.",
-                    Required:    true,
-                    Destination: &dbPort,
+                    Name:        "depth",
+                    Usage:       "Define the number of relationship hops (in either direction) from the focus tables to keep. This value will be used only in combination with [--focus].",
+                    Required:    false,
+                    Value:       1,
+                    Destination: &fetchOpts.focusDepth,
                 },
-                &cli.StringFlag{
-                    Name:        "dbName",
-                    Aliases:     []string{"n", "N"},
-                    Usage:       "Define the name of the database.",
-                    Required:    true,
-                    Destination: &dbName,
+                &cli.BoolFlag{
+                    Name:        "focusKeysOnly",
+                    Usage:       "Keep only the key columns (PK, FK, UQ) of the tables other than the focus ones. This value will be used only in combination with [--focus].",
+                    Required:    false,
+                    Value:       false,
+                    Destination: &fetchOpts.focusKeysOnly,
                 },
-                &cli.StringFlag{
-                    Name:        "dbUser",
-                    Aliases:     []string{"u", "U"},
-                    Usage:       "Define the user of the database.",
-                    Required:    true,
-                    Destination: &dbUser,
+                &cli.BoolFlag{
+                    Name:        "profile",
+                    Usage:       "Include the estimated row counts and the column statistics (null fraction, distinct values, most common values, histogram bounds) from pg_stats. No table is scanned.",
+                    Required:    false,
+                    Value:       false,
+                    Destination: &fetchOpts.profile,
                 },
-                &cli.StringFlag{
-                    Name:        "dbPass",
-                    Aliases:     []string{"s", "S"},
-                    Usage:       "Define the password of the database.",
-                    Required:    true,
-                    Destination: &dbPass,
+                &cli.BoolFlag{
+                    Name:        "classify",
+                    Usage:       "Tag the columns holding pii or sensitive information based on the classification rules of the configuration. Always enabled for the 'register' output type.",
+                    Required:    false,
+                    Value:       false,
+                    Destination: &fetchOpts.classify,
                 },
-                &cli.StringFlag{
-                    Name:        "dbSchema",
-                    Aliases:     []string{"c", "C"},
-                    Usage:       "Define the schema of the database.",
+                &cli.IntFlag{
+                    Name:        "sample",
+                    Usage:       "Include up to N example values per column, fetched with TABLESAMPLE. The columns matching the redaction list of the configuration are never sampled.",
                     Required:    false,
-                    Value:       "public",
-                    Destination: &dbSchema,
+                    Value:       0,
+                    Destination: &fetchOpts.sample.Limit,
                 },
-            },
+            }, append(filterFlags(), databaseFlags(&dbOpts)...)...),
             Action: func(c *cli.Context) error {
-                dbConn, err = postgres.New(dbHost, dbPort, dbName, dbUser, dbPass)
+                fetchOpts.focusTables = c.StringSlice("focus")
+                fetchOpts.classify = fetchOpts.classify || tmplEngine.IsClassifiedOutput(outputType)
+
+                err := configureFetchOptions(cfg, &fetchOpts)
                 if err != nil {
                     err.LogError()
                     return err.Err
                 }
 
-                session := dbConn.NewSession(nil)
-                repo := postgresRepository.New(dbName, dbSchema, session)
-                decoratorService := decorator.New(repo, dbName)
+                filter, err := newFilter(c)
+                if err != nil {
+                    err.LogError()
+                    return err.Err
+                }
 
-                templateValues, err := decoratorService.GetTables().
-                    GetColumnsOfAllTables().
-                    GetPrimaryKeyOfAllTables().
-                    GetForeignKeyOfAllTables().
-                    GetGenericConstraintsOfAllTables().
-                    PrepareTemplateValues()
+                repo, err := newRepository(dbOpts, filter)
                 if err != nil {
                     err.LogError()
                     return err.Err
                 }
 
+                templateValues, err := fetchTemplateValues(repo, dbOpts, fetchOpts)
+                if err != nil {
+                    err.LogError()
+                    return err.Err
+                }
+                templateValues.DiagramDetail = diagramDetail
+
+                if tmplEngine.IsDirectoryOutput(outputType) {
+                    var pages map[string]string
+                    pages, err = tmplEngine.GenerateSite(templateValues)
+                    if err != nil {
+                        err.LogError()
+                        return err.Err
+                    }
+
+                    err = writeSite(output, outputFile, pages)
+                    if err != nil {
+                        err.LogError()
+                        return err.Err
+                    }
+
+                    return nil
+                }
+
                 var generatedData string
                 generatedData, err = tmplEngine.Generate(outputType, templateValues)
                 if err != nil {
@@ -139,29 +197,200 @@ func main() {
                     return err.Err
                 }
 
-                switch output {
-                case "std":
-                    fmt.Print(generatedData)
-                case "file":
+                if output == "file" {
                     fileExtension := filepath.Ext(outputFile)
-                    if fmt.Sprintf(".%v", outputType) != fileExtension {
+                    if tmplEngine.FileExtension(outputType) != fileExtension {
                         err = &pkg.Error{Err: fmt.Errorf("incompatible types provided for output type '%v' and file extention '%v'", outputType, fileExtension)}
                         err.LogError()
                         return err.Err
                     }
+                }
+
+                err = writeOutput(output, outputFile, generatedData)
+                if err != nil {
+                    err.LogError()
+                    return err.Err
+                }
+
+                return nil
+            },
+        },
+        {
+            Name:  "lint",
+            Usage: "Run the configured lint rules against the schema of the database.",
+            Flags: append([]cli.Flag{
+                &cli.StringFlag{
+                    Name:        "format",
+                    Aliases:     []string{"t", "T"},
+                    Usage:       "Define the format of the lint findings. Allowed values: ['text', 'json', 'sarif']",
+                    Required:    false,
+                    Value:       "text",
+                    Destination: &lintFormat,
+                },
+                &cli.StringFlag{
+                    Name:        "migrations",
+                    Aliases:     []string{"m", "M"},
+                    Usage:       "Define the directory of the sql migrations. When provided, the sarif findings point to the migration file that created each object.",
+                    Required:    false,
+                    Destination: &migrationsDir,
+                },
+                &cli.StringFlag{
+                    Name:        "output",
+                    Aliases:     []string{"o", "O"},
+                    Usage:       "Define the output of the lint findings. Allowed values: ['std', 'file']",
+                    Required:    false,
+                    Value:       "std",
+                    Destination: &output,
+                },
+                &cli.StringFlag{
+                    Name:        "outputFile",
+                    Aliases:     []string{"f", "F"},
+                    Usage:       "Define the output file to publish the findings to. This value will be used only in combination when [--output file] is provided.",
+                    Required:    false,
+                    Value:       "std",
+                    Destination: &outputFile,
+                },
+            }, append(filterFlags(), databaseFlags(&dbOpts)...)...),
+            Action: func(c *cli.Context) error {
+                err := configureFetchOptions(cfg, &fetchOpts)
+                if err != nil {
+                    err.LogError()
+                    return err.Err
+                }
 
-                    fileWriteErr := ioutil.WriteFile(outputFile, []byte(generatedData), 0755)
-                    if fileWriteErr != nil {
-                        err = &pkg.Error{Err: fmt.Errorf("failed to write data to file '%v' with error: %v", outputFile, fileWriteErr)}
+                lintService, err := lint.New(dbOpts.schema, lintSettings(cfg))
+                if err != nil {
+                    err.LogError()
+                    return err.Err
+                }
+
+                if migrationsDir != "" {
+                    err = lintService.LoadMigrations(migrationsDir)
+                    if err != nil {
                         err.LogError()
                         return err.Err
                     }
-                default:
-                    err = &pkg.Error{Err: fmt.Errorf("invalid output source was provided: %v", output)}
+                }
+
+                filter, err := newFilter(c)
+                if err != nil {
+                    err.LogError()
+                    return err.Err
+                }
+
+                repo, err := newRepository(dbOpts, filter)
+                if err != nil {
+                    err.LogError()
+                    return err.Err
+                }
+
+                templateValues, err := fetchTemplateValues(repo, dbOpts, fetchOpts)
+                if err != nil {
+                    err.LogError()
+                    return err.Err
+                }
+
+                findings := lintService.Run(templateValues)
+
+                var report string
+                report, err = lintService.Render(lintFormat, findings)
+                if err != nil {
+                    err.LogError()
+                    return err.Err
+                }
+
+                err = writeOutput(output, outputFile, report)
+                if err != nil {
+                    err.LogError()
+                    return err.Err
+                }
+
+                if lint.HasErrors(findings) {
+                    return fmt.Errorf("lint finished with errors")
+                }
+
+                return nil
+            },
+        },
+        {
+            Name:  "check-integrity",
+            Usage: "Count the orphan rows of the child tables for each declared or inferred relationship of the database.",
+            Flags: append([]cli.Flag{
+                &cli.BoolFlag{
+                    Name:        "inferRelationships",
+                    Usage:       "Check the relationships inferred from the naming conventions of the columns (i.e. 'customer_id' to 'customers.id') as well.",
+                    Required:    false,
+                    Value:       false,
+                    Destination: &fetchOpts.inferRelationships,
+                },
+                &cli.IntFlag{
+                    Name:        "limit",
+                    Usage:       "Define the maximum number of orphan rows to count for each relationship.",
+                    Required:    false,
+                    Value:       defaultOrphanLimit,
+                    Destination: &orphanLimit,
+                },
+                &cli.DurationFlag{
+                    Name:        "timeout",
+                    Usage:       "Define the statement timeout of the check of each relationship.",
+                    Required:    false,
+                    Value:       defaultCheckTimeout,
+                    Destination: &checkTimeout,
+                },
+                &cli.StringFlag{
+                    Name:        "output",
+                    Aliases:     []string{"o", "O"},
+                    Usage:       "Define the output of the integrity report. Allowed values: ['std', 'file']",
+                    Required:    false,
+                    Value:       "std",
+                    Destination: &output,
+                },
+                &cli.StringFlag{
+                    Name:        "outputFile",
+                    Aliases:     []string{"f", "F"},
+                    Usage:       "Define the output file to publish the report to. This value will be used only in combination when [--output file] is provided.",
+                    Required:    false,
+                    Value:       "std",
+                    Destination: &outputFile,
+                },
+            }, append(filterFlags(), databaseFlags(&dbOpts)...)...),
+            Action: func(c *cli.Context) error {
+                err := configureFetchOptions(cfg, &fetchOpts)
+                if err != nil {
+                    err.LogError()
+                    return err.Err
+                }
+
+                filter, err := newFilter(c)
+                if err != nil {
+                    err.LogError()
+                    return err.Err
+                }
+
+                repo, err := newRepository(dbOpts, filter)
+                if err != nil {
+                    err.LogError()
+                    return err.Err
+                }
+
+                templateValues, err := fetchTemplateValues(repo, dbOpts, fetchOpts)
+                if err != nil {
+                    err.LogError()
+                    return err.Err
+                }
+
+                results := integrity.New(repo, orphanLimit, checkTimeout).Check(templateValues)
+
+                err = writeOutput(output, outputFile, integrity.Render(results))
+                if err != nil {
                     err.LogError()
                     return err.Err
                 }
 
+                if integrity.HasIssues(results) {
+                    return fmt.Errorf("integrity check found orphan rows or failed checks")
+                }
+
                 return nil
             },
         },
@@ -189,3 +418,149 @@ func info(app *cli.App, cfg *config.Config) {
     app.Usage = cfg.Application.Usage
     app.Version = cfg.Application.Version
 }
+
+// lintSettings converts the lint rules configuration to the settings of the lint service.
+func lintSettings(cfg *config.Config) map[string]lint.RuleSetting {
+    settings := make(map[string]lint.RuleSetting)
+    for _, rule := range cfg.Lint.Rules {
+        settings[rule.ID] = lint.RuleSetting{
+            Enabled:  rule.Enabled,
+            Severity: rule.Severity,
+        }
+    }
+
+    return settings
+}
+
+// configureFetchOptions completes the fetch options with the tag rules, the classification rules (when the columns are
+// classified) and the sampling details of the configuration.
+func configureFetchOptions(cfg *config.Config, fetchOpts *fetchOptions) *pkg.Error {
+    for _, tag := range cfg.Tags {
+        tables, err := pkg.NewPatterns(tag.Tables)
+        if err != nil {
+            return err
+        }
+
+        columns, err := pkg.NewPatterns(tag.Columns)
+        if err != nil {
+            return err
+        }
+
+        fetchOpts.tagRules = append(fetchOpts.tagRules, decorator.TagRule{
+            Tables:  tables,
+            Columns: columns,
+            Tags:    tag.Tags,
+        })
+    }
+
+    for _, area := range cfg.SubjectAreas {
+        tables, err := pkg.NewPatterns(area.Tables)
+        if err != nil {
+            return err
+        }
+
+        fetchOpts.subjectAreas = append(fetchOpts.subjectAreas, decorator.SubjectArea{
+            Name:        area.Name,
+            Description: area.Description,
+            Tables:      tables,
+            Tags:        area.Tags,
+        })
+    }
+
+    if fetchOpts.classify {
+        classifierService, err := newClassifier(cfg)
+        if err != nil {
+            return err
+        }
+        fetchOpts.classifier = classifierService
+    }
+
+    redactColumns, err := pkg.NewPatterns(cfg.Sampling.Redact.Columns)
+    if err != nil {
+        return err
+    }
+
+    fetchOpts.sample.Percentage = cfg.Sampling.Percentage
+    if fetchOpts.sample.Percentage <= 0 {
+        fetchOpts.sample.Percentage = defaultSamplePercentage
+    }
+
+    fetchOpts.sample.Timeout = cfg.Sampling.Timeout
+    if fetchOpts.sample.Timeout <= 0 {
+        fetchOpts.sample.Timeout = defaultSampleTimeout
+    }
+
+    fetchOpts.sample.RedactColumns = redactColumns
+    fetchOpts.sample.RedactTags = cfg.Sampling.Redact.Tags
+
+    return nil
+}
+
+// newClassifier creates the classifier service based on the classification rules of the configuration.
+func newClassifier(cfg *config.Config) (*classifier.Service, *pkg.Error) {
+    var classificationRules []classifier.Rule
+    for _, rule := range cfg.Classification.Rules {
+        namePatterns, err := pkg.NewPatterns(rule.Names)
+        if err != nil {
+            return nil, err
+        }
+
+        var valuePatterns []*regexp.Regexp
+        for _, val := range rule.Values {
+            re, compileErr := regexp.Compile(val)
+            if compileErr != nil {
+                return nil, &pkg.Error{Err: fmt.Errorf("invalid value pattern '%v' provided for classification category '%v': %v", val, rule.Category, compileErr)}
+            }
+
+            valuePatterns = append(valuePatterns, re)
+        }
+
+        classificationRules = append(classificationRules, classifier.Rule{
+            Category:      rule.Category,
+            Sensitivity:   rule.Sensitivity,
+            NamePatterns:  namePatterns,
+            ValuePatterns: valuePatterns,
+        })
+    }
+
+    return classifier.New(classificationRules)
+}
+
+// writeOutput publishes the generated data to the requested output.
+func writeOutput(output string, outputFile string, data string) *pkg.Error {
+    switch output {
+    case "std":
+        fmt.Print(data)
+    case "file":
+        fileWriteErr := ioutil.WriteFile(outputFile, []byte(data), 0755)
+        if fileWriteErr != nil {
+            return &pkg.Error{Err: fmt.Errorf("failed to write data to file '%v' with error: %v", outputFile, fileWriteErr)}
+        }
+    default:
+        return &pkg.Error{Err: fmt.Errorf("invalid output source was provided: %v", output)}
+    }
+
+    return nil
+}
+
+// writeSite writes the pages of the site to the output directory, creating any missing directories.
+func writeSite(output string, outputDir string, pages map[string]string) *pkg.Error {
+    if output != "file" {
+        return &pkg.Error{Err: fmt.Errorf("the site can only be written to a directory with [--output file --outputFile <directory>]")}
+    }
+
+    for pagePath, data := range pages {
+        filePath := filepath.Join(outputDir, filepath.FromSlash(pagePath))
+        mkdirErr := os.MkdirAll(filepath.Dir(filePath), 0755)
+        if mkdirErr != nil {
+            return &pkg.Error{Err: fmt.Errorf("failed to create directory '%v' with error: %v", filepath.Dir(filePath), mkdirErr)}
+        }
+
+        fileWriteErr := ioutil.WriteFile(filePath, []byte(data), 0644)
+        if fileWriteErr != nil {
+            return &pkg.Error{Err: fmt.Errorf("failed to write data to file '%v' with error: %v", filePath, fileWriteErr)}
+        }
+    }
+
+    return nil
+}
@@ -2,6 +2,7 @@ package config
 
 import (
     "io/ioutil"
+    "time"
 
     "github.com/eujoy/data-dict/pkg"
     "gopkg.in/yaml.v2"
@@ -9,7 +10,12 @@ import (
 
 // Config describes the configuration of the service.
 type Config struct {
-    Application application `yaml:"application"`
+    Application    application    `yaml:"application"`
+    Lint           lint           `yaml:"lint"`
+    Sampling       sampling       `yaml:"sampling"`
+    Tags           []tag          `yaml:"tags"`
+    Classification classification `yaml:"classification"`
+    SubjectAreas   []subjectArea  `yaml:"subjectAreas"`
 }
 
 // application describes the main details of the service.
@@ -26,6 +32,59 @@ type author struct {
     Email string `yaml:"email"`
 }
 
+// lint describes the configuration of the schema lint rules.
+type lint struct {
+    Rules []lintRule `yaml:"rules"`
+}
+
+// lintRule describes whether a lint rule is enabled and the severity of its findings.
+type lintRule struct {
+    ID       string `yaml:"id"`
+    Enabled  bool   `yaml:"enabled"`
+    Severity string `yaml:"severity"`
+}
+
+// sampling describes how the example values of the columns are sampled.
+type sampling struct {
+    Percentage float64       `yaml:"percentage"`
+    Timeout    time.Duration `yaml:"timeout"`
+    Redact     redact        `yaml:"redact"`
+}
+
+// redact describes the columns that must never be sampled, either by their name or by their tags.
+type redact struct {
+    Columns []string `yaml:"columns"`
+    Tags    []string `yaml:"tags"`
+}
+
+// tag describes the tags assigned to the tables (and columns) matching the respective patterns.
+type tag struct {
+    Tables  []string `yaml:"tables"`
+    Columns []string `yaml:"columns"`
+    Tags    []string `yaml:"tags"`
+}
+
+// classification describes the rules for detecting the columns holding pii or sensitive information.
+type classification struct {
+    Rules []classificationRule `yaml:"rules"`
+}
+
+// classificationRule describes the name patterns and the value regular expressions of a classification category.
+type classificationRule struct {
+    Category    string   `yaml:"category"`
+    Sensitivity string   `yaml:"sensitivity"`
+    Names       []string `yaml:"names"`
+    Values      []string `yaml:"values"`
+}
+
+// subjectArea describes a group of tables, matched either by their names or by their tags.
+type subjectArea struct {
+    Name        string   `yaml:"name"`
+    Description string   `yaml:"description"`
+    Tables      []string `yaml:"tables"`
+    Tags        []string `yaml:"tags"`
+}
+
 // New creates and returns a configuration object for the service.
 func New(configFile string) (*Config, *pkg.Error) {
     var config *Config
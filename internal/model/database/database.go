@@ -1,19 +1,33 @@
 package database
 
-// TableDef describes the table related info required as they are retrieved from information_schema.tables.
+// TableDef describes the table related info required, along with the partitioning and row level security details, as
+// they are retrieved from pg_class, pg_partitioned_table and pg_inherits.
 type TableDef struct {
-    TableName string `db:"table_name"`
+    TableName         string  `db:"table_name"`
+    Comment           *string `db:"comment"`
+    PartitionStrategy *string `db:"partition_strategy"` // Can be "RANGE", "LIST" or "HASH" for partitioned tables
+    PartitionKey      *string `db:"partition_key"`
+    PartitionOf       *string `db:"partition_of"`
+    PartitionBound    *string `db:"partition_bound"`
+    RLSEnabled        bool    `db:"rls_enabled"`
+    RLSForced         bool    `db:"rls_forced"`
 }
 
 // ColumnDef describes the column related info as they are retrieved from information_schema.columns.
 type ColumnDef struct {
-    OrdinalPosition int      `db:"ordinal_position"`
-    ColumnName      string   `db:"column_name"`
-    Default         *string  `db:"column_default"`
-    IsNullable      string   `db:"is_nullable"` // Can be "YES" or "NO"
-    DataType        string   `db:"data_type"`
-    UDataType       string   `db:"udt_name"`
-    Comment         *string  `db:"comment"`
+    OrdinalPosition      int     `db:"ordinal_position"`
+    ColumnName           string  `db:"column_name"`
+    Default              *string `db:"column_default"`
+    IsNullable           string  `db:"is_nullable"` // Can be "YES" or "NO"
+    DataType             string  `db:"data_type"`
+    UDataType            string  `db:"udt_name"`
+    FormattedType        *string `db:"formatted_type"` // The type as returned by format_type(), e.g. "numeric(12,2)"
+    Comment              *string `db:"comment"`
+    IsIdentity           string  `db:"is_identity"`           // Can be "YES" or "NO"
+    IdentityGeneration   *string `db:"identity_generation"`   // Can be "ALWAYS" or "BY DEFAULT"
+    IsGenerated          string  `db:"is_generated"`          // Can be "ALWAYS" or "NEVER"
+    GenerationExpression *string `db:"generation_expression"`
+    DomainName           *string `db:"domain_name"`
 }
 
 // PKConstraintDef describes the columns that are part of the primary key of a table.
@@ -22,7 +36,8 @@ type PKConstraintDef struct {
     ColumnName     string `db:"column_name"`
 }
 
-// FKConstraintDef describes the definition of the foreign key constraints for a table.
+// FKConstraintDef describes a column pair of a foreign key constraint of a table as it is retrieved from pg_constraint.
+// The composite foreign keys are retrieved as one row per column pair, in the order of the key.
 type FKConstraintDef struct {
     ConstraintName    string `db:"constraint_name"`
     SourceTableName   string `db:"source_table_name"`
@@ -37,3 +52,148 @@ type GenericConstraintDef struct {
     ColumnName     string `db:"column_name"`
     ConstraintType string `db:"constraint_type"`
 }
+
+// IndexDef describes the definition of an index of a table as it is retrieved from pg_index.
+type IndexDef struct {
+    IndexName          string `db:"index_name"`
+    ColumnNames        string `db:"column_names"`         // Comma separated list of the key columns in the order of the index key, apart from the expressions.
+    IncludeColumnNames string `db:"include_column_names"` // Comma separated list of the non-key (INCLUDE) columns.
+    Method             string `db:"method"`               // Can be "btree", "hash", "gist", "gin", etc.
+    IsUnique           bool   `db:"is_unique"`
+    IsPrimary          bool   `db:"is_primary"`
+    IsPartial          bool   `db:"is_partial"`
+    HasExpressions     bool   `db:"has_expressions"`
+    Definition         string `db:"definition"`
+}
+
+// TriggerDef describes the definition of a trigger of a table as it is retrieved from pg_trigger.
+type TriggerDef struct {
+    TriggerName   string  `db:"trigger_name"`
+    Timing        string  `db:"timing"`  // Can be "BEFORE", "AFTER" or "INSTEAD OF"
+    Events        string  `db:"events"`  // Comma separated list of "INSERT", "UPDATE", "DELETE" and "TRUNCATE".
+    Level         string  `db:"level"`   // Can be "ROW" or "STATEMENT"
+    Enabled       string  `db:"enabled"` // Can be "ENABLED", "DISABLED", "REPLICA" or "ALWAYS"
+    FunctionOid   int64   `db:"function_oid"`
+    FunctionName  string  `db:"function_name"`
+    WhenCondition *string `db:"when_condition"`
+    Definition    string  `db:"definition"`
+}
+
+// RoutineDef describes a function or procedure of the schema as it is retrieved from pg_proc.
+type RoutineDef struct {
+    RoutineOid      int64   `db:"routine_oid"`
+    RoutineName     string  `db:"routine_name"`
+    Signature       string  `db:"signature"`
+    Kind            string  `db:"kind"` // Can be "FUNCTION", "PROCEDURE", "AGGREGATE" or "WINDOW"
+    ReturnType      *string `db:"return_type"`
+    Language        string  `db:"language"`
+    Volatility      string  `db:"volatility"` // Can be "IMMUTABLE", "STABLE" or "VOLATILE"
+    SecurityDefiner bool    `db:"security_definer"`
+    Comment         *string `db:"comment"`
+    Body            *string `db:"body"`
+}
+
+// GrantDef describes a privilege granted on a table, or on a column of it, as it is retrieved from
+// information_schema.role_table_grants and information_schema.column_privileges. The column is not set for the
+// privileges granted on the table as a whole.
+type GrantDef struct {
+    Grantee       string  `db:"grantee"`
+    PrivilegeType string  `db:"privilege_type"`
+    ColumnName    *string `db:"column_name"`
+    IsGrantable   string  `db:"is_grantable"` // Can be "YES" or "NO"
+}
+
+// PolicyDef describes a row level security policy of a table as it is retrieved from pg_policies.
+type PolicyDef struct {
+    PolicyName string  `db:"policy_name"`
+    Permissive string  `db:"permissive"` // Can be "PERMISSIVE" or "RESTRICTIVE"
+    Roles      string  `db:"roles"`      // Comma separated list of the roles the policy applies to.
+    Command    string  `db:"command"`    // Can be "ALL", "SELECT", "INSERT", "UPDATE" or "DELETE"
+    Using      *string `db:"using_expression"`
+    WithCheck  *string `db:"with_check_expression"`
+}
+
+// TableStatisticsDef describes the planner statistics of a table as they are retrieved from pg_class.
+type TableStatisticsDef struct {
+    EstimatedRows float64 `db:"estimated_rows"`
+}
+
+// ColumnStatisticsDef describes the planner statistics of a column as they are retrieved from pg_stats.
+type ColumnStatisticsDef struct {
+    ColumnName            string  `db:"column_name"`
+    NullFraction          float64 `db:"null_frac"`
+    DistinctValues        float64 `db:"n_distinct"` // Negative values describe the ratio of distinct values to rows.
+    MostCommonValues      *string `db:"most_common_vals"`
+    MostCommonFrequencies *string `db:"most_common_freqs"`
+    HistogramBounds       *string `db:"histogram_bounds"`
+}
+
+// SequenceDef describes a sequence of the schema, along with the column that owns it (if any), as it is retrieved from
+// pg_sequence.
+type SequenceDef struct {
+    SequenceName  string  `db:"sequence_name"`
+    DataType      string  `db:"data_type"`
+    StartValue    int64   `db:"start_value"`
+    IncrementBy   int64   `db:"increment_by"`
+    MinValue      int64   `db:"min_value"`
+    MaxValue      int64   `db:"max_value"`
+    Cycle         bool    `db:"cycle"`
+    OwnedByTable  *string `db:"owned_by_table"`
+    OwnedByColumn *string `db:"owned_by_column"`
+}
+
+// ViewDef describes a view or materialized view of the schema, along with the tables and columns it depends on, as it
+// is retrieved from pg_class.
+type ViewDef struct {
+    ViewName     string              `db:"view_name"`
+    Kind         string              `db:"kind"` // Can be "VIEW" or "MATERIALIZED VIEW"
+    Comment      *string             `db:"comment"`
+    Dependencies []ViewDependencyDef `db:"-"`
+}
+
+// ViewDependencyDef describes a table (or view) and column that a view depends on, as it is retrieved from pg_depend
+// through the rewrite rule of the view. The column is not set when the view depends on the table as a whole.
+type ViewDependencyDef struct {
+    ViewName   string  `db:"view_name"`
+    TableName  string  `db:"table_name"`
+    TableKind  string  `db:"table_kind"` // Can be "TABLE", "VIEW" or "MATERIALIZED VIEW"
+    ColumnName *string `db:"column_name"`
+}
+
+// InheritanceDef describes the parent of a table that inherits it (INHERITS), as it is retrieved from pg_inherits.
+type InheritanceDef struct {
+    ChildTable  string `db:"child_table"`
+    ParentTable string `db:"parent_table"`
+}
+
+// DomainDef describes a domain of the schema, along with its check constraints, as it is retrieved from pg_type.
+type DomainDef struct {
+    DomainName  string                `db:"domain_name"`
+    BaseType    string                `db:"base_type"`
+    Default     *string               `db:"domain_default"`
+    NotNull     bool                  `db:"not_null"`
+    Comment     *string               `db:"comment"`
+    Constraints []DomainConstraintDef `db:"-"`
+}
+
+// CompositeTypeDef describes a composite type of the schema, along with its attributes, as it is retrieved from pg_type.
+type CompositeTypeDef struct {
+    TypeName   string                  `db:"type_name"`
+    Comment    *string                 `db:"comment"`
+    Attributes []CompositeAttributeDef `db:"-"`
+}
+
+// CompositeAttributeDef describes an attribute of a composite type as it is retrieved from pg_attribute.
+type CompositeAttributeDef struct {
+    TypeName        string `db:"type_name"`
+    OrdinalPosition int    `db:"ordinal_position"`
+    AttributeName   string `db:"attribute_name"`
+    DataType        string `db:"data_type"`
+}
+
+// DomainConstraintDef describes a check constraint of a domain as it is retrieved from pg_constraint.
+type DomainConstraintDef struct {
+    DomainName     string `db:"domain_name"`
+    ConstraintName string `db:"constraint_name"`
+    Definition     string `db:"definition"`
+}
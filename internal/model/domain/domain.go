@@ -2,36 +2,265 @@ package domain
 
 // TemplateValues describes the details required for the respective values required for the template.
 type TemplateValues struct {
-    DatabaseName string
-    TableList    []TableTmplValue
+    DatabaseName    string                 `json:"databaseName"`
+    SchemaName      string                 `json:"schemaName"`
+    DiagramDetail   string                 `json:"diagramDetail"`
+    Profiled        bool                   `json:"profiled"`
+    Sampled         bool                   `json:"sampled"`
+    TableList       []TableTmplValue       `json:"tableList,omitempty"`
+    SubjectAreaList []SubjectAreaTmplValue `json:"subjectAreaList,omitempty"`
+    AreaLinkList    []AreaLinkTmplValue    `json:"areaLinkList,omitempty"`
+    SequenceList    []SequenceTmplValue    `json:"sequenceList,omitempty"`
+    ViewList        []ViewTmplValue        `json:"viewList,omitempty"`
+    DomainList      []DomainTmplValue      `json:"domainList,omitempty"`
+    CustomTypeList  []CustomTypeTmplValue  `json:"customTypeList,omitempty"`
+    RoutineList     []RoutineTmplValue     `json:"routineList,omitempty"`
 }
 
 // TableTmplValue describes the table related values for the template.
 type TableTmplValue struct {
-    TableName       string
-    ColumnList      []ColumnTmplValue
-    ConstraintsList []ConstraintTmplValue
+    TableName       string                 `json:"tableName"`
+    Comment         string                 `json:"comment"`
+    Tags            []string               `json:"tags,omitempty"`
+    SubjectArea     string                 `json:"subjectArea"`
+    SubjectAreaID   string                 `json:"subjectAreaId"`
+    EstimatedRows   string                 `json:"estimatedRows"`
+    ColumnList      []ColumnTmplValue      `json:"columnList,omitempty"`
+    ConstraintsList []ConstraintTmplValue  `json:"constraintsList,omitempty"`
+    IndexList       []IndexTmplValue       `json:"indexList,omitempty"`
+    TriggerList     []TriggerTmplValue     `json:"triggerList,omitempty"`
+    RLSEnabled      bool                   `json:"rlsEnabled"`
+    RLSForced       bool                   `json:"rlsForced"`
+    GrantList       []GrantTmplValue       `json:"grantList,omitempty"`
+    PolicyList      []PolicyTmplValue      `json:"policyList,omitempty"`
+    ReferencedBy    []ReferenceTmplValue   `json:"referencedBy,omitempty"`
+    UsedByViews     []string               `json:"usedByViews,omitempty"`
+    InheritsFrom    []string               `json:"inheritsFrom,omitempty"`
+    InheritedBy     []string               `json:"inheritedBy,omitempty"`
+    Partitioning    *PartitioningTmplValue `json:"partitioning,omitempty"`
+    PartitionList   []PartitionTmplValue   `json:"partitionList,omitempty"`
+}
+
+// ViewTmplValue describes a view or materialized view, along with the tables (or views) it depends on, for the
+// template.
+type ViewTmplValue struct {
+    Name      string                    `json:"name"`
+    Kind      string                    `json:"kind"`
+    Comment   string                    `json:"comment"`
+    DependsOn []ViewDependencyTmplValue `json:"dependsOn,omitempty"`
+}
+
+// ViewDependencyTmplValue describes a table (or view) that a view depends on, along with the columns of it the view
+// uses, for the template. The dependency is shown when the table (or view) is documented as well.
+type ViewDependencyTmplValue struct {
+    Name    string   `json:"name"`
+    Kind    string   `json:"kind"`
+    Columns []string `json:"columns,omitempty"`
+    Shown   bool     `json:"shown"`
+}
+
+// PartitioningTmplValue describes the partition key and strategy of a partitioned table, or the parent table and the
+// bound of a partition, for the template.
+type PartitioningTmplValue struct {
+    Strategy         string `json:"strategy"`
+    Key              string `json:"key"`
+    PartitionOf      string `json:"partitionOf"`
+    PartitionOfShown bool   `json:"partitionOfShown"`
+    Bound            string `json:"bound"`
+}
+
+// PartitionTmplValue describes a partition of a partitioned table, along with its bound, for the template. The
+// partition is shown when it is documented as a table as well.
+type PartitionTmplValue struct {
+    Name  string `json:"name"`
+    Bound string `json:"bound"`
+    Shown bool   `json:"shown"`
 }
 
 // ColumnTmplValue describes the column related values for the template.
 type ColumnTmplValue struct {
-    Ordinal      int
-    Name         string
-    DataType     string
-    PK           bool
-    FK           bool
-    UQ           bool
-    NotNull      bool
-    DefaultValue string
-    Comment      string
+    Ordinal             int                     `json:"ordinal"`
+    Name                string                  `json:"name"`
+    DataType            string                  `json:"dataType"`
+    RawDataType         string                  `json:"rawDataType"`
+    PK                  bool                    `json:"pk"`
+    FK                  bool                    `json:"fk"`
+    InferredFK          bool                    `json:"inferredFk"`
+    UQ                  bool                    `json:"uq"`
+    NotNull             bool                    `json:"notNull"`
+    DefaultValue        string                  `json:"defaultValue"`
+    Identity            string                  `json:"identity"`
+    Generated           string                  `json:"generated"`
+    Sequence            string                  `json:"sequence"`
+    Domain              string                  `json:"domain"`
+    CustomType          string                  `json:"customType"`
+    Comment             string                  `json:"comment"`
+    Tags                []string                `json:"tags,omitempty"`
+    Sensitivity         string                  `json:"sensitivity"`
+    Profile             *ColumnProfileTmplValue `json:"profile,omitempty"`
+    Examples            []string                `json:"examples,omitempty"`
+    ExamplesRedacted    bool                    `json:"examplesRedacted"`
+    ExamplesUnavailable bool                    `json:"examplesUnavailable"`
+}
+
+// ColumnProfileTmplValue describes the statistics based profile of a column for the template.
+type ColumnProfileTmplValue struct {
+    NullFraction     string `json:"nullFraction"`
+    DistinctValues   string `json:"distinctValues"`
+    MostCommonValues string `json:"mostCommonValues"`
+    HistogramBounds  string `json:"histogramBounds"`
 }
 
 // ConstraintTmplValue describes the constraint values for the template.
 type ConstraintTmplValue struct {
-    Name             string
-    Type             string
-    Column           string
-    References       string
-    ReferencesTable  string
-    ReferencesColumn string
+    Name             string `json:"name"`
+    Type             string `json:"type"`
+    Column           string `json:"column"`
+    References       string `json:"references"`
+    ReferencesTable  string `json:"referencesTable"`
+    ReferencesColumn string `json:"referencesColumn"`
+    Inferred         bool   `json:"inferred"`
+}
+
+// ReferenceTmplValue describes an incoming reference to a table, from a (declared or inferred) foreign key column of
+// another table, for the template.
+type ReferenceTmplValue struct {
+    Name             string `json:"name"`
+    Table            string `json:"table"`
+    Column           string `json:"column"`
+    ReferencedColumn string `json:"referencedColumn"`
+    Inferred         bool   `json:"inferred"`
+}
+
+// IndexTmplValue describes the index values for the template.
+type IndexTmplValue struct {
+    Name           string   `json:"name"`
+    Columns        []string `json:"columns,omitempty"`
+    IncludeColumns []string `json:"includeColumns,omitempty"`
+    Method         string   `json:"method"`
+    Unique         bool     `json:"unique"`
+    Primary        bool     `json:"primary"`
+    Partial        bool     `json:"partial"`
+    Expression     bool     `json:"expression"`
+    Definition     string   `json:"definition"`
+}
+
+// TriggerTmplValue describes the trigger values for the template.
+type TriggerTmplValue struct {
+    Name       string   `json:"name"`
+    Timing     string   `json:"timing"`
+    Events     []string `json:"events,omitempty"`
+    Level      string   `json:"level"`
+    Enabled    string   `json:"enabled"`
+    Function   string   `json:"function"`
+    RoutineID  string   `json:"routineId"`
+    When       string   `json:"when"`
+    Definition string   `json:"definition"`
+}
+
+// GrantTmplValue describes a privilege granted on a table, or on a column of it, for the template.
+type GrantTmplValue struct {
+    Grantee   string `json:"grantee"`
+    Privilege string `json:"privilege"`
+    Column    string `json:"column"`
+    Grantable bool   `json:"grantable"`
+}
+
+// PolicyTmplValue describes a row level security policy of a table for the template.
+type PolicyTmplValue struct {
+    Name       string   `json:"name"`
+    Permissive string   `json:"permissive"`
+    Roles      []string `json:"roles,omitempty"`
+    Command    string   `json:"command"`
+    Using      string   `json:"using"`
+    WithCheck  string   `json:"withCheck"`
+}
+
+// RoutineTmplValue describes a function or procedure for the template. The body is set only when the bodies of the
+// routines are requested.
+type RoutineTmplValue struct {
+    ID              string `json:"id"`
+    Name            string `json:"name"`
+    Signature       string `json:"signature"`
+    Kind            string `json:"kind"`
+    ReturnType      string `json:"returnType"`
+    Language        string `json:"language"`
+    Volatility      string `json:"volatility"`
+    SecurityDefiner bool   `json:"securityDefiner"`
+    Comment         string `json:"comment"`
+    Body            string `json:"body"`
+}
+
+// SubjectAreaTmplValue describes a subject area (group of tables) along with its relationships for the template.
+type SubjectAreaTmplValue struct {
+    ID               string                      `json:"id"`
+    Name             string                      `json:"name"`
+    Description      string                      `json:"description"`
+    TableList        []TableTmplValue            `json:"tableList,omitempty"`
+    RelationshipList []AreaRelationshipTmplValue `json:"relationshipList,omitempty"`
+}
+
+// AreaRelationshipTmplValue describes a relationship of a subject area for the template. The stub table (and its
+// subject area) is set when one of the two tables belongs to another subject area.
+type AreaRelationshipTmplValue struct {
+    Table            string `json:"table"`
+    Column           string `json:"column"`
+    ReferencesTable  string `json:"referencesTable"`
+    ReferencesColumn string `json:"referencesColumn"`
+    Inferred         bool   `json:"inferred"`
+    StubTable        string `json:"stubTable"`
+    StubArea         string `json:"stubArea"`
+}
+
+// AreaLinkTmplValue describes the relationships from the tables of a subject area to the tables of another one for
+// the template.
+type AreaLinkTmplValue struct {
+    FromID        string `json:"fromId"`
+    From          string `json:"from"`
+    ToID          string `json:"toId"`
+    To            string `json:"to"`
+    Relationships int    `json:"relationships"`
+}
+
+// SequenceTmplValue describes a sequence, along with the column that owns it (if any), for the template.
+type SequenceTmplValue struct {
+    Name          string `json:"name"`
+    DataType      string `json:"dataType"`
+    StartValue    int64  `json:"startValue"`
+    IncrementBy   int64  `json:"incrementBy"`
+    MinValue      int64  `json:"minValue"`
+    MaxValue      int64  `json:"maxValue"`
+    Cycle         bool   `json:"cycle"`
+    OwnedByTable  string `json:"ownedByTable"`
+    OwnedByColumn string `json:"ownedByColumn"`
+}
+
+// DomainTmplValue describes a domain, along with its check constraints, for the template.
+type DomainTmplValue struct {
+    Name         string                 `json:"name"`
+    BaseType     string                 `json:"baseType"`
+    DefaultValue string                 `json:"defaultValue"`
+    NotNull      bool                   `json:"notNull"`
+    Comment      string                 `json:"comment"`
+    CheckList    []DomainCheckTmplValue `json:"checkList,omitempty"`
+}
+
+// DomainCheckTmplValue describes a check constraint of a domain for the template.
+type DomainCheckTmplValue struct {
+    Name       string `json:"name"`
+    Definition string `json:"definition"`
+}
+
+// CustomTypeTmplValue describes a user-defined composite type, along with its attributes, for the template.
+type CustomTypeTmplValue struct {
+    Name          string                    `json:"name"`
+    Comment       string                    `json:"comment"`
+    AttributeList []CustomAttributeTmplValue `json:"attributeList,omitempty"`
+}
+
+// CustomAttributeTmplValue describes an attribute of a user-defined composite type for the template.
+type CustomAttributeTmplValue struct {
+    Ordinal  int    `json:"ordinal"`
+    Name     string `json:"name"`
+    DataType string `json:"dataType"`
 }
@@ -1,36 +1,55 @@
 package postgres
 
 import (
+    "fmt"
+    "strings"
+    "time"
+
     "github.com/eujoy/data-dict/internal/model/database"
     "github.com/eujoy/data-dict/pkg"
     "github.com/gocraft/dbr/v2"
+    "github.com/lib/pq"
 )
 
 var (
+    queryStmtFetchTables = `
+    SELECT
+        c.relname AS table_name,
+        pg_catalog.obj_description(c.oid, 'pg_class') AS comment,
+        CASE pt.partstrat
+            WHEN 'r' THEN 'RANGE'
+            WHEN 'l' THEN 'LIST'
+            WHEN 'h' THEN 'HASH'
+        END AS partition_strategy,
+        regexp_replace(pg_catalog.pg_get_partkeydef(c.oid), '^[A-Z]+ ', '') AS partition_key,
+        parent.relname AS partition_of,
+        pg_catalog.pg_get_expr(c.relpartbound, c.oid) AS partition_bound,
+        c.relrowsecurity AS rls_enabled,
+        c.relforcerowsecurity AS rls_forced
+    FROM
+        pg_catalog.pg_class c
+        JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+        LEFT JOIN pg_catalog.pg_partitioned_table pt ON pt.partrelid = c.oid
+        LEFT JOIN pg_catalog.pg_inherits i ON i.inhrelid = c.oid AND c.relispartition
+        LEFT JOIN pg_catalog.pg_class parent ON parent.oid = i.inhparent
+    WHERE
+        n.nspname = ?
+        AND c.relkind IN ('r', 'p')
+    ORDER BY
+        c.relname`
+
     queryStmtFetchColumns = `
     SELECT
         co.*,
-        tmp.column_comment as comment
+        pg_catalog.col_description(a.attrelid, a.attnum) as comment,
+        pg_catalog.format_type(a.atttypid, a.atttypmod) as formatted_type
     FROM information_schema.columns co
-        LEFT JOIN (
-            SELECT
-                cols.column_name,
-                (
-                    SELECT
-                        pg_catalog.col_description(c.oid, cols.ordinal_position::int)
-                    FROM
-                        pg_catalog.pg_class c
-                    WHERE
-                        c.oid = (SELECT ('"' || cols.table_name || '"')::regclass::oid)
-                        AND c.relname = cols.table_name
-            ) AS column_comment
-            FROM
-                information_schema.columns cols
-            WHERE
-                cols.table_name = ?
-        ) AS tmp ON tmp.column_name = co.column_name
+        LEFT JOIN pg_catalog.pg_attribute a
+            ON a.attrelid = (quote_ident(co.table_schema) || '.' || quote_ident(co.table_name))::regclass
+            AND a.attname = co.column_name
     WHERE
-        table_name = ?`
+        co.table_schema = ?
+        AND co.table_name = ?`
     
     queryStmtFetchPKConstraints = `
     SELECT
@@ -48,22 +67,24 @@ var (
 
     queryStmtFetchFKConstraints = `
     SELECT
-        tc.constraint_name AS constraint_name,
-        tc.table_name AS source_table_name,
-        kcu.column_name AS source_column_name,
-        ccu.table_name AS foreign_table_name,
-        ccu.column_name AS foreign_column_name
+        con.conname AS constraint_name,
+        src.relname AS source_table_name,
+        sa.attname AS source_column_name,
+        tgt.relname AS foreign_table_name,
+        ta.attname AS foreign_column_name
     FROM
-        information_schema.table_constraints AS tc
-        JOIN information_schema.key_column_usage AS kcu
-            ON tc.constraint_name = kcu.constraint_name
-            AND tc.table_schema = kcu.table_schema
-        JOIN information_schema.constraint_column_usage AS ccu
-            ON ccu.constraint_name = tc.constraint_name
-            AND ccu.table_schema = tc.table_schema
+        pg_catalog.pg_constraint con
+        JOIN pg_catalog.pg_class src ON src.oid = con.conrelid
+        JOIN pg_catalog.pg_namespace n ON n.oid = src.relnamespace
+        JOIN pg_catalog.pg_class tgt ON tgt.oid = con.confrelid AND tgt.relnamespace = n.oid
+        CROSS JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS k(source_attnum, foreign_attnum, ord)
+        JOIN pg_catalog.pg_attribute sa ON sa.attrelid = con.conrelid AND sa.attnum = k.source_attnum
+        JOIN pg_catalog.pg_attribute ta ON ta.attrelid = con.confrelid AND ta.attnum = k.foreign_attnum
     WHERE
-        tc.constraint_type = 'FOREIGN KEY'
-        AND tc.table_name = ?`
+        con.contype = 'f'
+        AND n.nspname = ?
+        AND src.relname = ?
+    ORDER BY con.conname, k.ord`
 
     queryStmtGenericConstraints = `
     SELECT
@@ -80,6 +101,414 @@ var (
         tco.constraint_type != 'FOREIGN KEY'
         AND tco.constraint_type != 'PRIMARY KEY'
         AND tco.table_name = ?`
+
+    queryStmtFetchIndexes = `
+    SELECT
+        i.relname AS index_name,
+        array_to_string(
+            ARRAY(
+                SELECT
+                    a.attname
+                FROM
+                    unnest(ix.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord)
+                    JOIN pg_catalog.pg_attribute a
+                        ON a.attrelid = ix.indrelid
+                        AND a.attnum = k.attnum
+                WHERE
+                    k.ord <= ix.indnkeyatts
+                ORDER BY k.ord
+            ),
+            ','
+        ) AS column_names,
+        array_to_string(
+            ARRAY(
+                SELECT
+                    a.attname
+                FROM
+                    unnest(ix.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord)
+                    JOIN pg_catalog.pg_attribute a
+                        ON a.attrelid = ix.indrelid
+                        AND a.attnum = k.attnum
+                WHERE
+                    k.ord > ix.indnkeyatts
+                ORDER BY k.ord
+            ),
+            ','
+        ) AS include_column_names,
+        am.amname AS method,
+        ix.indisunique AS is_unique,
+        ix.indisprimary AS is_primary,
+        ix.indpred IS NOT NULL AS is_partial,
+        ix.indexprs IS NOT NULL AS has_expressions,
+        pg_catalog.pg_get_indexdef(ix.indexrelid) AS definition
+    FROM
+        pg_catalog.pg_index ix
+        JOIN pg_catalog.pg_class i ON i.oid = ix.indexrelid
+        JOIN pg_catalog.pg_am am ON am.oid = i.relam
+        JOIN pg_catalog.pg_class t ON t.oid = ix.indrelid
+        JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+    WHERE
+        n.nspname = ?
+        AND t.relname = ?`
+
+    queryStmtFetchTriggers = `
+    SELECT
+        tg.tgname AS trigger_name,
+        CASE
+            WHEN tg.tgtype & 2 <> 0 THEN 'BEFORE'
+            WHEN tg.tgtype & 64 <> 0 THEN 'INSTEAD OF'
+            ELSE 'AFTER'
+        END AS timing,
+        array_to_string(
+            ARRAY_REMOVE(
+                ARRAY[
+                    CASE WHEN tg.tgtype & 4 <> 0 THEN 'INSERT' END,
+                    CASE WHEN tg.tgtype & 16 <> 0 THEN 'UPDATE' END,
+                    CASE WHEN tg.tgtype & 8 <> 0 THEN 'DELETE' END,
+                    CASE WHEN tg.tgtype & 32 <> 0 THEN 'TRUNCATE' END
+                ],
+                NULL
+            ),
+            ','
+        ) AS events,
+        CASE WHEN tg.tgtype & 1 <> 0 THEN 'ROW' ELSE 'STATEMENT' END AS level,
+        CASE tg.tgenabled
+            WHEN 'O' THEN 'ENABLED'
+            WHEN 'D' THEN 'DISABLED'
+            WHEN 'R' THEN 'REPLICA'
+            WHEN 'A' THEN 'ALWAYS'
+        END AS enabled,
+        tg.tgfoid AS function_oid,
+        tg.tgfoid::regproc::text AS function_name,
+        substring(pg_catalog.pg_get_triggerdef(tg.oid, true) FROM 'WHEN \((.*)\) EXECUTE') AS when_condition,
+        pg_catalog.pg_get_triggerdef(tg.oid, true) AS definition
+    FROM
+        pg_catalog.pg_trigger tg
+        JOIN pg_catalog.pg_class t ON t.oid = tg.tgrelid
+        JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+    WHERE
+        n.nspname = ?
+        AND t.relname = ?
+        AND NOT tg.tgisinternal
+    ORDER BY
+        tg.tgname`
+
+    queryStmtFetchGrants = `
+    SELECT
+        tg.grantee,
+        tg.privilege_type,
+        NULL AS column_name,
+        tg.is_grantable
+    FROM
+        information_schema.role_table_grants tg
+    WHERE
+        tg.table_schema = ?
+        AND tg.table_name = ?
+    UNION ALL
+    SELECT
+        cp.grantee,
+        cp.privilege_type,
+        cp.column_name,
+        cp.is_grantable
+    FROM
+        information_schema.column_privileges cp
+    WHERE
+        cp.table_schema = ?
+        AND cp.table_name = ?
+        AND NOT EXISTS (
+            SELECT
+                1
+            FROM
+                information_schema.role_table_grants tg
+            WHERE
+                tg.table_schema = cp.table_schema
+                AND tg.table_name = cp.table_name
+                AND tg.grantee = cp.grantee
+                AND tg.privilege_type = cp.privilege_type
+        )
+    ORDER BY
+        grantee,
+        privilege_type,
+        column_name NULLS FIRST`
+
+    queryStmtFetchPolicies = `
+    SELECT
+        p.policyname AS policy_name,
+        p.permissive,
+        array_to_string(p.roles, ',') AS roles,
+        p.cmd AS command,
+        p.qual AS using_expression,
+        p.with_check AS with_check_expression
+    FROM
+        pg_catalog.pg_policies p
+    WHERE
+        p.schemaname = ?
+        AND p.tablename = ?
+    ORDER BY
+        p.policyname`
+
+    queryStmtFetchRoutines = `
+    SELECT
+        p.oid AS routine_oid,
+        p.proname AS routine_name,
+        p.proname || '(' || pg_catalog.pg_get_function_arguments(p.oid) || ')' AS signature,
+        CASE p.prokind
+            WHEN 'f' THEN 'FUNCTION'
+            WHEN 'p' THEN 'PROCEDURE'
+            WHEN 'a' THEN 'AGGREGATE'
+            WHEN 'w' THEN 'WINDOW'
+        END AS kind,
+        pg_catalog.pg_get_function_result(p.oid) AS return_type,
+        l.lanname AS language,
+        CASE p.provolatile
+            WHEN 'i' THEN 'IMMUTABLE'
+            WHEN 's' THEN 'STABLE'
+            WHEN 'v' THEN 'VOLATILE'
+        END AS volatility,
+        p.prosecdef AS security_definer,
+        pg_catalog.obj_description(p.oid, 'pg_proc') AS comment,
+        p.prosrc AS body
+    FROM
+        pg_catalog.pg_proc p
+        JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace
+        JOIN pg_catalog.pg_language l ON l.oid = p.prolang
+    WHERE
+        n.nspname = ?
+        AND NOT EXISTS (
+            SELECT
+                1
+            FROM
+                pg_catalog.pg_depend d
+            WHERE
+                d.classid = 'pg_catalog.pg_proc'::regclass
+                AND d.objid = p.oid
+                AND d.deptype = 'e'
+        )
+    ORDER BY
+        p.proname,
+        signature`
+
+    queryStmtFetchSequences = `
+    SELECT
+        s.relname AS sequence_name,
+        pg_catalog.format_type(seq.seqtypid, NULL) AS data_type,
+        seq.seqstart AS start_value,
+        seq.seqincrement AS increment_by,
+        seq.seqmin AS min_value,
+        seq.seqmax AS max_value,
+        seq.seqcycle AS cycle,
+        t.relname AS owned_by_table,
+        a.attname AS owned_by_column
+    FROM
+        pg_catalog.pg_sequence seq
+        JOIN pg_catalog.pg_class s ON s.oid = seq.seqrelid
+        JOIN pg_catalog.pg_namespace n ON n.oid = s.relnamespace
+        LEFT JOIN pg_catalog.pg_depend d
+            ON d.classid = 'pg_catalog.pg_class'::regclass
+            AND d.objid = s.oid
+            AND d.refclassid = 'pg_catalog.pg_class'::regclass
+            AND d.deptype IN ('a', 'i')
+        LEFT JOIN pg_catalog.pg_class t ON t.oid = d.refobjid
+        LEFT JOIN pg_catalog.pg_attribute a
+            ON a.attrelid = d.refobjid
+            AND a.attnum = d.refobjsubid
+    WHERE
+        n.nspname = ?
+    ORDER BY
+        s.relname`
+
+    queryStmtFetchViews = `
+    SELECT
+        c.relname AS view_name,
+        CASE c.relkind WHEN 'm' THEN 'MATERIALIZED VIEW' ELSE 'VIEW' END AS kind,
+        pg_catalog.obj_description(c.oid, 'pg_class') AS comment
+    FROM
+        pg_catalog.pg_class c
+        JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+    WHERE
+        n.nspname = ?
+        AND c.relkind IN ('v', 'm')
+    ORDER BY
+        c.relname`
+
+    queryStmtFetchViewDependencies = `
+    SELECT DISTINCT
+        v.relname AS view_name,
+        t.relname AS table_name,
+        CASE t.relkind
+            WHEN 'v' THEN 'VIEW'
+            WHEN 'm' THEN 'MATERIALIZED VIEW'
+            ELSE 'TABLE'
+        END AS table_kind,
+        a.attname AS column_name
+    FROM
+        pg_catalog.pg_class v
+        JOIN pg_catalog.pg_namespace n ON n.oid = v.relnamespace
+        JOIN pg_catalog.pg_rewrite rw ON rw.ev_class = v.oid
+        JOIN pg_catalog.pg_depend d
+            ON d.classid = 'pg_catalog.pg_rewrite'::regclass
+            AND d.objid = rw.oid
+            AND d.refclassid = 'pg_catalog.pg_class'::regclass
+        JOIN pg_catalog.pg_class t
+            ON t.oid = d.refobjid
+            AND t.oid <> v.oid
+            AND t.relnamespace = v.relnamespace
+        LEFT JOIN pg_catalog.pg_attribute a
+            ON a.attrelid = t.oid
+            AND a.attnum = d.refobjsubid
+            AND d.refobjsubid > 0
+    WHERE
+        n.nspname = ?
+        AND v.relkind IN ('v', 'm')
+        AND t.relkind IN ('r', 'p', 'v', 'm')
+    ORDER BY
+        view_name,
+        table_name,
+        column_name`
+
+    queryStmtFetchInheritance = `
+    SELECT
+        c.relname AS child_table,
+        p.relname AS parent_table
+    FROM
+        pg_catalog.pg_inherits i
+        JOIN pg_catalog.pg_class c ON c.oid = i.inhrelid
+        JOIN pg_catalog.pg_class p ON p.oid = i.inhparent
+        JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+    WHERE
+        n.nspname = ?
+        AND NOT c.relispartition
+    ORDER BY
+        c.relname,
+        i.inhseqno`
+
+    queryStmtFetchDomains = `
+    SELECT
+        t.typname AS domain_name,
+        pg_catalog.format_type(t.typbasetype, t.typtypmod) AS base_type,
+        t.typdefault AS domain_default,
+        t.typnotnull AS not_null,
+        pg_catalog.obj_description(t.oid, 'pg_type') AS comment
+    FROM
+        pg_catalog.pg_type t
+        JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+    WHERE
+        t.typtype = 'd'
+        AND n.nspname = ?
+    ORDER BY
+        t.typname`
+
+    queryStmtFetchDomainConstraints = `
+    SELECT
+        t.typname AS domain_name,
+        c.conname AS constraint_name,
+        pg_catalog.pg_get_constraintdef(c.oid, true) AS definition
+    FROM
+        pg_catalog.pg_constraint c
+        JOIN pg_catalog.pg_type t ON t.oid = c.contypid
+        JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+    WHERE
+        c.contype = 'c'
+        AND n.nspname = ?
+    ORDER BY
+        t.typname,
+        c.conname`
+
+    queryStmtFetchCompositeTypes = `
+    SELECT
+        t.typname AS type_name,
+        pg_catalog.obj_description(t.oid, 'pg_type') AS comment
+    FROM
+        pg_catalog.pg_type t
+        JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+        JOIN pg_catalog.pg_class c ON c.oid = t.typrelid
+    WHERE
+        t.typtype = 'c'
+        AND c.relkind = 'c'
+        AND n.nspname = ?
+    ORDER BY
+        t.typname`
+
+    queryStmtFetchCompositeAttributes = `
+    SELECT
+        t.typname AS type_name,
+        a.attnum AS ordinal_position,
+        a.attname AS attribute_name,
+        pg_catalog.format_type(a.atttypid, a.atttypmod) AS data_type
+    FROM
+        pg_catalog.pg_type t
+        JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+        JOIN pg_catalog.pg_class c ON c.oid = t.typrelid
+        JOIN pg_catalog.pg_attribute a ON a.attrelid = c.oid
+    WHERE
+        t.typtype = 'c'
+        AND c.relkind = 'c'
+        AND a.attnum > 0
+        AND NOT a.attisdropped
+        AND n.nspname = ?
+    ORDER BY
+        t.typname,
+        a.attnum`
+
+    queryStmtFetchTableStatistics = `
+    SELECT
+        c.reltuples AS estimated_rows
+    FROM
+        pg_catalog.pg_class c
+        JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+    WHERE
+        n.nspname = ?
+        AND c.relname = ?`
+
+    queryStmtFetchColumnStatistics = `
+    SELECT DISTINCT ON (s.attname)
+        s.attname AS column_name,
+        s.null_frac,
+        s.n_distinct,
+        s.most_common_vals::text AS most_common_vals,
+        s.most_common_freqs::text AS most_common_freqs,
+        s.histogram_bounds::text AS histogram_bounds
+    FROM
+        pg_catalog.pg_stats s
+    WHERE
+        s.schemaname = ?
+        AND s.tablename = ?
+    ORDER BY
+        s.attname,
+        s.inherited`
+
+    // The identifiers are quoted before being placed in the statement, since they cannot be passed as arguments.
+    queryStmtFetchSampleValues = `
+    SELECT DISTINCT
+        left(%[1]s::text, ?) AS sample_value
+    FROM
+        %[2]s TABLESAMPLE SYSTEM (?)
+    WHERE
+        %[1]s IS NOT NULL
+    LIMIT ?`
+
+    queryStmtCountOrphanRows = `
+    SELECT
+        count(*) AS orphan_rows
+    FROM (
+        SELECT
+            1
+        FROM
+            %[1]s c
+        WHERE
+            %[3]s
+            AND NOT EXISTS (
+                SELECT
+                    1
+                FROM
+                    %[2]s p
+                WHERE
+                    %[4]s
+            )
+        LIMIT ?
+    ) AS orphans`
+
+    sampleValueMaxLength = 64
 )
 
 // Repo describes the repository structure for the postgres client.
@@ -87,6 +516,16 @@ type Repo struct {
     dbName string
     dbSchema string
     session *dbr.Session
+    filter Filter
+}
+
+// Filter describes the patterns of the tables and columns to include or exclude. Empty include patterns include
+// everything, while the exclude patterns take precedence over the include ones.
+type Filter struct {
+    IncludeTables  pkg.Patterns
+    ExcludeTables  pkg.Patterns
+    IncludeColumns pkg.Patterns
+    ExcludeColumns pkg.Patterns
 }
 
 // New creates and returns a new repository structure.
@@ -98,34 +537,56 @@ func New(dbName string, dbSchema string, session *dbr.Session) *Repo {
     }
 }
 
-// GetTables retrieves and returns the tables of the database.
+// WithFilter sets the filter applied to the tables and columns retrieved from the database.
+func (r *Repo) WithFilter(filter Filter) *Repo {
+    r.filter = filter
+    return r
+}
+
+// FiltersTables checks whether some of the tables are filtered out by the include or exclude patterns.
+func (r *Repo) FiltersTables() bool {
+    return len(r.filter.IncludeTables) > 0 || len(r.filter.ExcludeTables) > 0
+}
+
+// GetTables retrieves and returns the tables of the database, along with the partition key and strategy of the
+// partitioned tables and the parent and bound of the partitions.
 func (r *Repo) GetTables() ([]database.TableDef, *pkg.Error) {
     var tableDefList []database.TableDef
-    _, execErr := r.session.Select("table_name").
-        From("information_schema.tables").
-        Where("table_catalog = ?", r.dbName).
-        Where("table_schema = ?", r.dbSchema).
-        Where("table_type = ?", "BASE TABLE").
+    _, execErr := r.session.SelectBySql(queryStmtFetchTables, r.dbSchema).
         Load(&tableDefList)
     if execErr != nil {
         err := &pkg.Error{Err: execErr}
         return []database.TableDef{}, err
     }
 
-    return tableDefList, nil
+    var filteredTableDefList []database.TableDef
+    for _, tb := range tableDefList {
+        if isIncluded(r.filter.IncludeTables, r.filter.ExcludeTables, tb.TableName) {
+            filteredTableDefList = append(filteredTableDefList, tb)
+        }
+    }
+
+    return filteredTableDefList, nil
 }
 
 // GetColumnsOfTable retrieves and returns tha column details of a table.
 func (r *Repo) GetColumnsOfTable(tableName string) ([]database.ColumnDef, *pkg.Error) {
     var columnDefList []database.ColumnDef
-    _, execErr := r.session.SelectBySql(queryStmtFetchColumns, r.dbSchema + "." + tableName, tableName).
+    _, execErr := r.session.SelectBySql(queryStmtFetchColumns, r.dbSchema, tableName).
         Load(&columnDefList)
     if execErr != nil {
         err := &pkg.Error{Err: execErr}
         return []database.ColumnDef{}, err
     }
 
-    return columnDefList, nil
+    var filteredColumnDefList []database.ColumnDef
+    for _, col := range columnDefList {
+        if isIncluded(r.filter.IncludeColumns, r.filter.ExcludeColumns, col.ColumnName, tableName+"."+col.ColumnName) {
+            filteredColumnDefList = append(filteredColumnDefList, col)
+        }
+    }
+
+    return filteredColumnDefList, nil
 }
 
 // GetPrimaryKeysOfTable retrieves and returns tha primary key details of a table.
@@ -144,7 +605,7 @@ func (r *Repo) GetPrimaryKeysOfTable(tableName string) ([]database.PKConstraintD
 // GetForeignKeysOfTable retrieves and returns tha foreign key details of a table.
 func (r *Repo) GetForeignKeysOfTable(tableName string) ([]database.FKConstraintDef, *pkg.Error) {
     var fkConstraintList []database.FKConstraintDef
-    _, execErr := r.session.SelectBySql(queryStmtFetchFKConstraints, tableName).
+    _, execErr := r.session.SelectBySql(queryStmtFetchFKConstraints, r.dbSchema, tableName).
         Load(&fkConstraintList)
     if execErr != nil {
         err := &pkg.Error{Err: execErr}
@@ -166,3 +627,332 @@ func (r *Repo) GetGenericConstraintsOfTable(tableName string) ([]database.Generi
 
     return genConstraintList, nil
 }
+
+// GetIndexesOfTable retrieves and returns tha index details of a table.
+func (r *Repo) GetIndexesOfTable(tableName string) ([]database.IndexDef, *pkg.Error) {
+    var indexDefList []database.IndexDef
+    _, execErr := r.session.SelectBySql(queryStmtFetchIndexes, r.dbSchema, tableName).
+        Load(&indexDefList)
+    if execErr != nil {
+        err := &pkg.Error{Err: execErr}
+        return []database.IndexDef{}, err
+    }
+
+    return indexDefList, nil
+}
+
+// GetTriggersOfTable retrieves and returns the (user defined) triggers of a table.
+func (r *Repo) GetTriggersOfTable(tableName string) ([]database.TriggerDef, *pkg.Error) {
+    var triggerDefList []database.TriggerDef
+    _, execErr := r.session.SelectBySql(queryStmtFetchTriggers, r.dbSchema, tableName).
+        Load(&triggerDefList)
+    if execErr != nil {
+        err := &pkg.Error{Err: execErr}
+        return []database.TriggerDef{}, err
+    }
+
+    return triggerDefList, nil
+}
+
+// GetGrantsOfTable retrieves and returns the privileges granted on a table, along with the privileges granted only on
+// some of its columns.
+func (r *Repo) GetGrantsOfTable(tableName string) ([]database.GrantDef, *pkg.Error) {
+    var grantDefList []database.GrantDef
+    _, execErr := r.session.SelectBySql(queryStmtFetchGrants, r.dbSchema, tableName, r.dbSchema, tableName).
+        Load(&grantDefList)
+    if execErr != nil {
+        err := &pkg.Error{Err: execErr}
+        return []database.GrantDef{}, err
+    }
+
+    var filteredGrantDefList []database.GrantDef
+    for _, grant := range grantDefList {
+        if grant.ColumnName == nil || isIncluded(r.filter.IncludeColumns, r.filter.ExcludeColumns, *grant.ColumnName, tableName+"."+*grant.ColumnName) {
+            filteredGrantDefList = append(filteredGrantDefList, grant)
+        }
+    }
+
+    return filteredGrantDefList, nil
+}
+
+// GetPoliciesOfTable retrieves and returns the row level security policies of a table.
+func (r *Repo) GetPoliciesOfTable(tableName string) ([]database.PolicyDef, *pkg.Error) {
+    var policyDefList []database.PolicyDef
+    _, execErr := r.session.SelectBySql(queryStmtFetchPolicies, r.dbSchema, tableName).
+        Load(&policyDefList)
+    if execErr != nil {
+        err := &pkg.Error{Err: execErr}
+        return []database.PolicyDef{}, err
+    }
+
+    return policyDefList, nil
+}
+
+// GetRoutines retrieves and returns the functions and procedures of the schema. The routines that are part of an
+// extension are skipped.
+func (r *Repo) GetRoutines() ([]database.RoutineDef, *pkg.Error) {
+    var routineDefList []database.RoutineDef
+    _, execErr := r.session.SelectBySql(queryStmtFetchRoutines, r.dbSchema).
+        Load(&routineDefList)
+    if execErr != nil {
+        err := &pkg.Error{Err: execErr}
+        return []database.RoutineDef{}, err
+    }
+
+    return routineDefList, nil
+}
+
+// GetSequences retrieves and returns the sequences of the schema, along with the columns owning them. The sequences
+// owned by excluded tables or columns are skipped.
+func (r *Repo) GetSequences() ([]database.SequenceDef, *pkg.Error) {
+    var sequenceDefList []database.SequenceDef
+    _, execErr := r.session.SelectBySql(queryStmtFetchSequences, r.dbSchema).
+        Load(&sequenceDefList)
+    if execErr != nil {
+        err := &pkg.Error{Err: execErr}
+        return []database.SequenceDef{}, err
+    }
+
+    var filteredSequenceDefList []database.SequenceDef
+    for _, seq := range sequenceDefList {
+        if seq.OwnedByTable != nil && seq.OwnedByColumn != nil {
+            tableIncluded := isIncluded(r.filter.IncludeTables, r.filter.ExcludeTables, *seq.OwnedByTable)
+            columnIncluded := isIncluded(r.filter.IncludeColumns, r.filter.ExcludeColumns, *seq.OwnedByColumn, *seq.OwnedByTable+"."+*seq.OwnedByColumn)
+            if !tableIncluded || !columnIncluded {
+                continue
+            }
+        }
+
+        filteredSequenceDefList = append(filteredSequenceDefList, seq)
+    }
+
+    return filteredSequenceDefList, nil
+}
+
+// GetViews retrieves and returns the views and materialized views of the schema, along with the tables (or views) and
+// columns of the schema they depend on.
+func (r *Repo) GetViews() ([]database.ViewDef, *pkg.Error) {
+    var viewDefList []database.ViewDef
+    _, execErr := r.session.SelectBySql(queryStmtFetchViews, r.dbSchema).
+        Load(&viewDefList)
+    if execErr != nil {
+        err := &pkg.Error{Err: execErr}
+        return []database.ViewDef{}, err
+    }
+
+    var viewDependencyDefList []database.ViewDependencyDef
+    _, execErr = r.session.SelectBySql(queryStmtFetchViewDependencies, r.dbSchema).
+        Load(&viewDependencyDefList)
+    if execErr != nil {
+        err := &pkg.Error{Err: execErr}
+        return []database.ViewDef{}, err
+    }
+
+    for i := range viewDefList {
+        for _, dep := range viewDependencyDefList {
+            if dep.ViewName == viewDefList[i].ViewName {
+                viewDefList[i].Dependencies = append(viewDefList[i].Dependencies, dep)
+            }
+        }
+    }
+
+    return viewDefList, nil
+}
+
+// GetInheritance retrieves and returns the parents of the tables that inherit other tables. The partitions are not
+// included. The inheritance relationships from or to excluded tables are skipped.
+func (r *Repo) GetInheritance() ([]database.InheritanceDef, *pkg.Error) {
+    var inheritanceDefList []database.InheritanceDef
+    _, execErr := r.session.SelectBySql(queryStmtFetchInheritance, r.dbSchema).
+        Load(&inheritanceDefList)
+    if execErr != nil {
+        err := &pkg.Error{Err: execErr}
+        return []database.InheritanceDef{}, err
+    }
+
+    var filteredInheritanceDefList []database.InheritanceDef
+    for _, inh := range inheritanceDefList {
+        childIncluded := isIncluded(r.filter.IncludeTables, r.filter.ExcludeTables, inh.ChildTable)
+        parentIncluded := isIncluded(r.filter.IncludeTables, r.filter.ExcludeTables, inh.ParentTable)
+        if childIncluded && parentIncluded {
+            filteredInheritanceDefList = append(filteredInheritanceDefList, inh)
+        }
+    }
+
+    return filteredInheritanceDefList, nil
+}
+
+// GetDomains retrieves and returns the domains of the schema, along with their check constraints.
+func (r *Repo) GetDomains() ([]database.DomainDef, *pkg.Error) {
+    var domainDefList []database.DomainDef
+    _, execErr := r.session.SelectBySql(queryStmtFetchDomains, r.dbSchema).
+        Load(&domainDefList)
+    if execErr != nil {
+        err := &pkg.Error{Err: execErr}
+        return []database.DomainDef{}, err
+    }
+
+    var domainConstraintDefList []database.DomainConstraintDef
+    _, execErr = r.session.SelectBySql(queryStmtFetchDomainConstraints, r.dbSchema).
+        Load(&domainConstraintDefList)
+    if execErr != nil {
+        err := &pkg.Error{Err: execErr}
+        return []database.DomainDef{}, err
+    }
+
+    for i := range domainDefList {
+        for _, constr := range domainConstraintDefList {
+            if constr.DomainName == domainDefList[i].DomainName {
+                domainDefList[i].Constraints = append(domainDefList[i].Constraints, constr)
+            }
+        }
+    }
+
+    return domainDefList, nil
+}
+
+// GetCompositeTypes retrieves and returns the composite types of the schema, along with their attributes.
+func (r *Repo) GetCompositeTypes() ([]database.CompositeTypeDef, *pkg.Error) {
+    var compositeTypeDefList []database.CompositeTypeDef
+    _, execErr := r.session.SelectBySql(queryStmtFetchCompositeTypes, r.dbSchema).
+        Load(&compositeTypeDefList)
+    if execErr != nil {
+        err := &pkg.Error{Err: execErr}
+        return []database.CompositeTypeDef{}, err
+    }
+
+    var compositeAttributeDefList []database.CompositeAttributeDef
+    _, execErr = r.session.SelectBySql(queryStmtFetchCompositeAttributes, r.dbSchema).
+        Load(&compositeAttributeDefList)
+    if execErr != nil {
+        err := &pkg.Error{Err: execErr}
+        return []database.CompositeTypeDef{}, err
+    }
+
+    for i := range compositeTypeDefList {
+        for _, attr := range compositeAttributeDefList {
+            if attr.TypeName == compositeTypeDefList[i].TypeName {
+                compositeTypeDefList[i].Attributes = append(compositeTypeDefList[i].Attributes, attr)
+            }
+        }
+    }
+
+    return compositeTypeDefList, nil
+}
+
+// GetStatisticsOfTable retrieves and returns tha planner statistics of a table without scanning it.
+func (r *Repo) GetStatisticsOfTable(tableName string) (database.TableStatisticsDef, *pkg.Error) {
+    var tableStatistics database.TableStatisticsDef
+    _, execErr := r.session.SelectBySql(queryStmtFetchTableStatistics, r.dbSchema, tableName).
+        Load(&tableStatistics)
+    if execErr != nil {
+        err := &pkg.Error{Err: execErr}
+        return database.TableStatisticsDef{}, err
+    }
+
+    return tableStatistics, nil
+}
+
+// GetColumnStatisticsOfTable retrieves and returns tha planner statistics of the columns of a table without scanning it.
+func (r *Repo) GetColumnStatisticsOfTable(tableName string) ([]database.ColumnStatisticsDef, *pkg.Error) {
+    var columnStatisticsList []database.ColumnStatisticsDef
+    _, execErr := r.session.SelectBySql(queryStmtFetchColumnStatistics, r.dbSchema, tableName).
+        Load(&columnStatisticsList)
+    if execErr != nil {
+        err := &pkg.Error{Err: execErr}
+        return []database.ColumnStatisticsDef{}, err
+    }
+
+    return columnStatisticsList, nil
+}
+
+// GetSampleValuesOfColumn retrieves and returns a few distinct example values of a column. The values are fetched from
+// a sample of the table pages and the query is cancelled when it exceeds the provided timeout.
+func (r *Repo) GetSampleValuesOfColumn(tableName string, columnName string, percentage float64, limit int, timeout time.Duration) ([]string, *pkg.Error) {
+    query := fmt.Sprintf(
+        queryStmtFetchSampleValues,
+        pq.QuoteIdentifier(columnName),
+        r.qualifiedTableName(tableName),
+    )
+
+    var sampleValues []string
+    execErr := r.loadWithTimeout(timeout, &sampleValues, query, sampleValueMaxLength, percentage, limit)
+    if execErr != nil {
+        err := &pkg.Error{Err: fmt.Errorf("failed to sample values of column '%v.%v' with error: %v", tableName, columnName, execErr)}
+        return []string{}, err
+    }
+
+    return sampleValues, nil
+}
+
+// CountOrphanRows counts the rows of the child table whose (non null) key has no matching row in the parent table.
+// Counting stops at the provided limit and the query is cancelled when it exceeds the provided timeout.
+func (r *Repo) CountOrphanRows(childTable string, childColumns []string, parentTable string, parentColumns []string, limit int, timeout time.Duration) (int, *pkg.Error) {
+    if len(childColumns) == 0 || len(childColumns) != len(parentColumns) {
+        return 0, &pkg.Error{Err: fmt.Errorf("invalid relationship provided between '%v' and '%v'", childTable, parentTable)}
+    }
+
+    var notNullConditions, joinConditions []string
+    for i := range childColumns {
+        childColumn := "c." + pq.QuoteIdentifier(childColumns[i])
+        notNullConditions = append(notNullConditions, childColumn+" IS NOT NULL")
+        joinConditions = append(joinConditions, "p."+pq.QuoteIdentifier(parentColumns[i])+" = "+childColumn)
+    }
+
+    query := fmt.Sprintf(
+        queryStmtCountOrphanRows,
+        r.qualifiedTableName(childTable),
+        r.qualifiedTableName(parentTable),
+        strings.Join(notNullConditions, " AND "),
+        strings.Join(joinConditions, " AND "),
+    )
+
+    var orphanRows int
+    execErr := r.loadWithTimeout(timeout, &orphanRows, query, limit)
+    if execErr != nil {
+        err := &pkg.Error{Err: fmt.Errorf("failed to count orphan rows of '%v' referencing '%v' with error: %v", childTable, parentTable, execErr)}
+        return 0, err
+    }
+
+    return orphanRows, nil
+}
+
+// loadWithTimeout executes the query in a transaction, which is cancelled when it exceeds the provided timeout, and
+// loads the results to the provided value.
+func (r *Repo) loadWithTimeout(timeout time.Duration, value interface{}, query string, args ...interface{}) error {
+    tx, beginErr := r.session.Begin()
+    if beginErr != nil {
+        return beginErr
+    }
+    defer tx.RollbackUnlessCommitted()
+
+    _, execErr := tx.Exec(fmt.Sprintf("SET LOCAL statement_timeout = %d", timeout.Milliseconds()))
+    if execErr != nil {
+        return execErr
+    }
+
+    _, execErr = tx.SelectBySql(query, args...).Load(value)
+    return execErr
+}
+
+// qualifiedTableName returns the quoted name of a table qualified with the schema of the repository.
+func (r *Repo) qualifiedTableName(tableName string) string {
+    return pq.QuoteIdentifier(r.dbSchema) + "." + pq.QuoteIdentifier(tableName)
+}
+
+// isIncluded checks whether any of the names is matched by the include patterns (if any) and none of them is matched
+// by the exclude patterns.
+func isIncluded(include pkg.Patterns, exclude pkg.Patterns, names ...string) bool {
+    included := len(include) == 0
+    for _, name := range names {
+        if exclude.Match(name) {
+            return false
+        }
+
+        if include.Match(name) {
+            included = true
+        }
+    }
+
+    return included
+}
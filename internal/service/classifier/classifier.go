@@ -0,0 +1,91 @@
+package classifier
+
+import (
+    "fmt"
+    "regexp"
+
+    "github.com/eujoy/data-dict/pkg"
+)
+
+const (
+    // SensitivityPII describes the columns that hold personally identifiable information.
+    SensitivityPII = "pii"
+    // SensitivitySensitive describes the columns that hold sensitive, but not personal, information.
+    SensitivitySensitive = "sensitive"
+)
+
+// Rule describes how the columns of a category are detected, either by their name or by their sampled values.
+type Rule struct {
+    Category      string
+    Sensitivity   string
+    NamePatterns  pkg.Patterns
+    ValuePatterns []*regexp.Regexp
+}
+
+// Service describes the classifier service which tags the columns holding pii or sensitive information.
+type Service struct {
+    rules []Rule
+}
+
+// New creates and returns a new classifier service.
+func New(rules []Rule) (*Service, *pkg.Error) {
+    for _, r := range rules {
+        if r.Sensitivity != SensitivityPII && r.Sensitivity != SensitivitySensitive {
+            return nil, &pkg.Error{Err: fmt.Errorf("invalid sensitivity '%v' provided for classification category: %v", r.Sensitivity, r.Category)}
+        }
+    }
+
+    return &Service{rules: rules}, nil
+}
+
+// ClassifyByName returns the tags (sensitivity and category) of the rules whose name patterns match the column.
+func (s *Service) ClassifyByName(tableName string, columnName string) []string {
+    var tags []string
+    for _, r := range s.rules {
+        if r.NamePatterns.Match(columnName) || r.NamePatterns.Match(tableName+"."+columnName) {
+            tags = append(tags, r.Sensitivity, r.Category)
+        }
+    }
+
+    return tags
+}
+
+// ClassifyByValues returns the tags (sensitivity and category) of the rules whose value patterns match all the
+// provided sample values of a column.
+func (s *Service) ClassifyByValues(values []string) []string {
+    if len(values) == 0 {
+        return nil
+    }
+
+    var tags []string
+    for _, r := range s.rules {
+        if len(r.ValuePatterns) == 0 {
+            continue
+        }
+
+        allMatch := true
+        for _, val := range values {
+            if !matchAny(r.ValuePatterns, val) {
+                allMatch = false
+                break
+            }
+        }
+
+        if allMatch {
+            tags = append(tags, r.Sensitivity, r.Category)
+        }
+    }
+
+    return tags
+}
+
+// matchAny checks whether the value matches any of the regular expressions.
+func matchAny(patterns []*regexp.Regexp, value string) bool {
+    for _, re := range patterns {
+        if re.MatchString(value) {
+            return true
+        }
+    }
+
+    return false
+}
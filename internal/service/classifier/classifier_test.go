@@ -0,0 +1,115 @@
+package classifier
+
+import (
+    "reflect"
+    "regexp"
+    "testing"
+
+    "github.com/eujoy/data-dict/pkg"
+)
+
+// newTestService creates a classifier service with an email and a card number rule.
+func newTestService(t *testing.T) *Service {
+    emailNames, err := pkg.NewPatterns([]string{"*email*"})
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err.Err)
+    }
+
+    cardNames, err := pkg.NewPatterns([]string{"payments.number"})
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err.Err)
+    }
+
+    s, err := New([]Rule{
+        {
+            Category:      "email",
+            Sensitivity:   SensitivityPII,
+            NamePatterns:  emailNames,
+            ValuePatterns: []*regexp.Regexp{regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)},
+        },
+        {
+            Category:      "card",
+            Sensitivity:   SensitivitySensitive,
+            NamePatterns:  cardNames,
+            ValuePatterns: []*regexp.Regexp{regexp.MustCompile(`^[0-9]{16}$`), regexp.MustCompile(`^[0-9]{4}( [0-9]{4}){3}$`)},
+        },
+    })
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err.Err)
+    }
+
+    return s
+}
+
+func TestNewInvalidSensitivity(t *testing.T) {
+    _, err := New([]Rule{{Category: "email", Sensitivity: "secret"}})
+    if err == nil {
+        t.Fatal("expected an error for the invalid sensitivity")
+    }
+}
+
+func TestClassifyByName(t *testing.T) {
+    testCases := map[string]struct {
+        tableName  string
+        columnName string
+        expected   []string
+    }{
+        "column name matches": {
+            tableName:  "users",
+            columnName: "Contact_Email",
+            expected:   []string{"pii", "email"},
+        },
+        "table.column name matches": {
+            tableName:  "payments",
+            columnName: "number",
+            expected:   []string{"sensitive", "card"},
+        },
+        "same column of another table does not match": {
+            tableName:  "invoices",
+            columnName: "number",
+            expected:   nil,
+        },
+    }
+
+    s := newTestService(t)
+    for name, tc := range testCases {
+        t.Run(name, func(t *testing.T) {
+            if actual := s.ClassifyByName(tc.tableName, tc.columnName); !reflect.DeepEqual(actual, tc.expected) {
+                t.Errorf("expected tags %v, got %v", tc.expected, actual)
+            }
+        })
+    }
+}
+
+func TestClassifyByValues(t *testing.T) {
+    testCases := map[string]struct {
+        values   []string
+        expected []string
+    }{
+        "all the values match": {
+            values:   []string{"a@example.com", "b@example.org"},
+            expected: []string{"pii", "email"},
+        },
+        "some of the values do not match": {
+            values:   []string{"a@example.com", "not an email"},
+            expected: nil,
+        },
+        "values match any of the patterns of the rule": {
+            values:   []string{"4111111111111111", "4111 1111 1111 1111"},
+            expected: []string{"sensitive", "card"},
+        },
+        "no values are never classified": {
+            values:   nil,
+            expected: nil,
+        },
+    }
+
+    s := newTestService(t)
+    for name, tc := range testCases {
+        t.Run(name, func(t *testing.T) {
+            if actual := s.ClassifyByValues(tc.values); !reflect.DeepEqual(actual, tc.expected) {
+                t.Errorf("expected tags %v, got %v", tc.expected, actual)
+            }
+        })
+    }
+}
@@ -0,0 +1,181 @@
+package decorator
+
+import (
+    "fmt"
+    "regexp"
+    "sort"
+    "strings"
+
+    "github.com/eujoy/data-dict/internal/model/domain"
+    "github.com/eujoy/data-dict/pkg"
+)
+
+// otherSubjectArea is the subject area of the tables that do not belong to any of the configured ones.
+const otherSubjectArea = "Other"
+
+var areaIDRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// SubjectArea describes a group of tables, matched either by their names or by their tags.
+type SubjectArea struct {
+    Name        string
+    Description string
+    Tables      pkg.Patterns
+    Tags        []string
+}
+
+// GroupIntoSubjectAreas assigns each of the tables that have been already retrieved to the first subject area that
+// matches either its name or any of its tags. The tables that do not match any subject area are assigned to the
+// "Other" one.
+func (s *Service) GroupIntoSubjectAreas(subjectAreas []SubjectArea) *Service {
+    if s.err != nil {
+        return s
+    }
+
+    areaNames := make(map[string]bool)
+    for _, area := range subjectAreas {
+        if strings.TrimSpace(area.Name) == "" {
+            s.err = &pkg.Error{Err: fmt.Errorf("subject area without a name provided")}
+            return s
+        }
+
+        if areaNames[area.Name] || area.Name == otherSubjectArea {
+            s.err = &pkg.Error{Err: fmt.Errorf("subject area '%v' is defined more than once", area.Name)}
+            return s
+        }
+        areaNames[area.Name] = true
+    }
+
+    s.subjectAreas = subjectAreas
+    s.tableAreaMap = make(map[string]string)
+    for _, tb := range s.tableDefList {
+        s.tableAreaMap[tb.TableName] = otherSubjectArea
+        for _, area := range subjectAreas {
+            if area.Tables.Match(tb.TableName) || hasAnyTag(s.tableTagMap[tb.TableName], area.Tags) {
+                s.tableAreaMap[tb.TableName] = area.Name
+                break
+            }
+        }
+    }
+
+    return s
+}
+
+// prepareSubjectAreas groups the tables of the template values to the subject areas, along with the relationships of
+// each subject area and the links among them. The subject areas keep the order in which they have been configured.
+func (s *Service) prepareSubjectAreas(tableList []domain.TableTmplValue) ([]domain.SubjectAreaTmplValue, []domain.AreaLinkTmplValue) {
+    var areaNames []string
+    for _, area := range s.subjectAreas {
+        areaNames = append(areaNames, area.Name)
+    }
+    areaNames = append(areaNames, otherSubjectArea)
+
+    areaPosition := make(map[string]int)
+    var subjectAreaList []domain.SubjectAreaTmplValue
+    for i, name := range areaNames {
+        areaPosition[name] = i
+        subjectArea := domain.SubjectAreaTmplValue{ID: subjectAreaID(name), Name: name}
+        if i < len(s.subjectAreas) {
+            subjectArea.Description = s.subjectAreas[i].Description
+        }
+
+        subjectAreaList = append(subjectAreaList, subjectArea)
+    }
+
+    for _, tb := range tableList {
+        pos := areaPosition[s.tableAreaMap[tb.TableName]]
+        subjectAreaList[pos].TableList = append(subjectAreaList[pos].TableList, tb)
+    }
+
+    linkPosition := make(map[string]int)
+    var areaLinkList []domain.AreaLinkTmplValue
+    for _, tb := range tableList {
+        fromArea := s.tableAreaMap[tb.TableName]
+        for _, constr := range tb.ConstraintsList {
+            toArea, ok := s.tableAreaMap[constr.ReferencesTable]
+            if constr.ReferencesTable == "" || !ok {
+                continue
+            }
+
+            rel := domain.AreaRelationshipTmplValue{
+                Table:            tb.TableName,
+                Column:           constr.Column,
+                ReferencesTable:  constr.ReferencesTable,
+                ReferencesColumn: constr.ReferencesColumn,
+                Inferred:         constr.Inferred,
+            }
+
+            if fromArea == toArea {
+                pos := areaPosition[fromArea]
+                subjectAreaList[pos].RelationshipList = append(subjectAreaList[pos].RelationshipList, rel)
+                continue
+            }
+
+            outgoing := rel
+            outgoing.StubTable, outgoing.StubArea = constr.ReferencesTable, toArea
+            subjectAreaList[areaPosition[fromArea]].RelationshipList = append(subjectAreaList[areaPosition[fromArea]].RelationshipList, outgoing)
+
+            incoming := rel
+            incoming.StubTable, incoming.StubArea = tb.TableName, fromArea
+            subjectAreaList[areaPosition[toArea]].RelationshipList = append(subjectAreaList[areaPosition[toArea]].RelationshipList, incoming)
+
+            linkKey := fromArea + "\x00" + toArea
+            pos, ok := linkPosition[linkKey]
+            if !ok {
+                pos = len(areaLinkList)
+                linkPosition[linkKey] = pos
+                areaLinkList = append(areaLinkList, domain.AreaLinkTmplValue{
+                    FromID: subjectAreaID(fromArea),
+                    From:   fromArea,
+                    ToID:   subjectAreaID(toArea),
+                    To:     toArea,
+                })
+            }
+            areaLinkList[pos].Relationships++
+        }
+    }
+
+    var nonEmptyAreaList []domain.SubjectAreaTmplValue
+    for _, area := range subjectAreaList {
+        if len(area.TableList) > 0 {
+            nonEmptyAreaList = append(nonEmptyAreaList, area)
+        }
+    }
+
+    sort.SliceStable(areaLinkList, func(i int, j int) bool {
+        if areaLinkList[i].From != areaLinkList[j].From {
+            return areaPosition[areaLinkList[i].From] < areaPosition[areaLinkList[j].From]
+        }
+        return areaPosition[areaLinkList[i].To] < areaPosition[areaLinkList[j].To]
+    })
+
+    return nonEmptyAreaList, areaLinkList
+}
+
+// subjectAreaPosition returns the position of the subject area of the table, in the order the areas are configured.
+func (s *Service) subjectAreaPosition(tableName string) int {
+    for i, area := range s.subjectAreas {
+        if area.Name == s.tableAreaMap[tableName] {
+            return i
+        }
+    }
+
+    return len(s.subjectAreas)
+}
+
+// subjectAreaID converts the name of a subject area to an identifier usable both as an anchor and as a diagram node.
+func subjectAreaID(name string) string {
+    return "area_" + strings.Trim(areaIDRegex.ReplaceAllString(strings.ToLower(name), "_"), "_")
+}
+
+// hasAnyTag checks whether any of the wanted tags is part of the tag list.
+func hasAnyTag(tagList []string, wanted []string) bool {
+    for _, tag := range wanted {
+        for _, existing := range tagList {
+            if existing == tag {
+                return true
+            }
+        }
+    }
+
+    return false
+}
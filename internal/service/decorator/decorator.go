@@ -1,8 +1,10 @@
 package decorator
 
 import (
+    "fmt"
     "sort"
     "strings"
+    "time"
 
     "github.com/eujoy/data-dict/internal/model/database"
     "github.com/eujoy/data-dict/internal/model/domain"
@@ -15,6 +17,41 @@ type repo interface {
     GetPrimaryKeysOfTable(tableName string) ([]database.PKConstraintDef, *pkg.Error)
     GetForeignKeysOfTable(tableName string) ([]database.FKConstraintDef, *pkg.Error)
     GetGenericConstraintsOfTable(tableName string) ([]database.GenericConstraintDef, *pkg.Error)
+    GetIndexesOfTable(tableName string) ([]database.IndexDef, *pkg.Error)
+    GetTriggersOfTable(tableName string) ([]database.TriggerDef, *pkg.Error)
+    GetGrantsOfTable(tableName string) ([]database.GrantDef, *pkg.Error)
+    GetPoliciesOfTable(tableName string) ([]database.PolicyDef, *pkg.Error)
+    GetRoutines() ([]database.RoutineDef, *pkg.Error)
+    GetSequences() ([]database.SequenceDef, *pkg.Error)
+    GetViews() ([]database.ViewDef, *pkg.Error)
+    GetInheritance() ([]database.InheritanceDef, *pkg.Error)
+    GetDomains() ([]database.DomainDef, *pkg.Error)
+    GetCompositeTypes() ([]database.CompositeTypeDef, *pkg.Error)
+    GetStatisticsOfTable(tableName string) (database.TableStatisticsDef, *pkg.Error)
+    GetColumnStatisticsOfTable(tableName string) ([]database.ColumnStatisticsDef, *pkg.Error)
+    GetSampleValuesOfColumn(tableName string, columnName string, percentage float64, limit int, timeout time.Duration) ([]string, *pkg.Error)
+}
+
+type classifier interface {
+    ClassifyByName(tableName string, columnName string) []string
+    ClassifyByValues(values []string) []string
+}
+
+// TagRule describes the tags to assign to the tables and columns matching the respective patterns. When no column
+// patterns are provided, the tags are assigned to the matching tables themselves.
+type TagRule struct {
+    Tables  pkg.Patterns
+    Columns pkg.Patterns
+    Tags    []string
+}
+
+// SampleOptions describes how the example values of the columns are sampled and which columns must never be sampled.
+type SampleOptions struct {
+    Limit         int
+    Percentage    float64
+    Timeout       time.Duration
+    RedactColumns pkg.Patterns
+    RedactTags    []string
 }
 
 // Service describes the decorator service for preparing and generating the template values.
@@ -24,10 +61,37 @@ type Service struct {
 
     databaseName            string
     tableDefList            []database.TableDef
+    partitionDefMap         map[string][]database.TableDef
     columnDefMap            map[string][]database.ColumnDef
     primaryKeyDefMap        map[string][]database.PKConstraintDef
     foreignKeyDefMap        map[string][]database.FKConstraintDef
+    inferredForeignKeyMap   map[string][]database.FKConstraintDef
     genericConstraintDefMap map[string][]database.GenericConstraintDef
+    indexDefMap             map[string][]database.IndexDef
+    triggerDefMap           map[string][]database.TriggerDef
+    grantDefMap             map[string][]database.GrantDef
+    policyDefMap            map[string][]database.PolicyDef
+    routineDefList          []database.RoutineDef
+    routineBodies           bool
+    sequenceDefList         []database.SequenceDef
+    viewDefList             []database.ViewDef
+    inheritanceDefList      []database.InheritanceDef
+    domainDefList           []database.DomainDef
+    compositeTypeDefList    []database.CompositeTypeDef
+    profiled                bool
+    tableStatisticsMap      map[string]database.TableStatisticsDef
+    columnStatisticsMap     map[string]map[string]database.ColumnStatisticsDef
+    tableTagMap             map[string][]string
+    columnTagMap            map[string]map[string][]string
+    sampled                 bool
+    sampleValueMap          map[string]map[string][]string
+    redactedColumnMap       map[string]map[string]bool
+    sampleFailedMap         map[string]map[string]bool
+    focusTableMap           map[string]bool
+    relatedObjectsOnly      bool
+    keysOnlyNeighbors       bool
+    subjectAreas            []SubjectArea
+    tableAreaMap            map[string]string
 }
 
 // New creates and returns a new decorator service.
@@ -35,7 +99,19 @@ func New(repo repo, databaseName string) *Service {
     columnDefMap := make(map[string][]database.ColumnDef)
     primaryKeyDefMap := make(map[string][]database.PKConstraintDef)
     foreignKeyDefMap := make(map[string][]database.FKConstraintDef)
+    inferredForeignKeyMap := make(map[string][]database.FKConstraintDef)
     genericConstraintDefMap := make(map[string][]database.GenericConstraintDef)
+    indexDefMap := make(map[string][]database.IndexDef)
+    triggerDefMap := make(map[string][]database.TriggerDef)
+    grantDefMap := make(map[string][]database.GrantDef)
+    policyDefMap := make(map[string][]database.PolicyDef)
+    tableStatisticsMap := make(map[string]database.TableStatisticsDef)
+    columnStatisticsMap := make(map[string]map[string]database.ColumnStatisticsDef)
+    tableTagMap := make(map[string][]string)
+    columnTagMap := make(map[string]map[string][]string)
+    sampleValueMap := make(map[string]map[string][]string)
+    redactedColumnMap := make(map[string]map[string]bool)
+    sampleFailedMap := make(map[string]map[string]bool)
 
     return &Service{
         repo:                    repo,
@@ -44,7 +120,19 @@ func New(repo repo, databaseName string) *Service {
         columnDefMap:            columnDefMap,
         primaryKeyDefMap:        primaryKeyDefMap,
         foreignKeyDefMap:        foreignKeyDefMap,
+        inferredForeignKeyMap:   inferredForeignKeyMap,
         genericConstraintDefMap: genericConstraintDefMap,
+        indexDefMap:             indexDefMap,
+        triggerDefMap:           triggerDefMap,
+        grantDefMap:             grantDefMap,
+        policyDefMap:            policyDefMap,
+        tableStatisticsMap:      tableStatisticsMap,
+        columnStatisticsMap:     columnStatisticsMap,
+        tableTagMap:             tableTagMap,
+        columnTagMap:            columnTagMap,
+        sampleValueMap:          sampleValueMap,
+        redactedColumnMap:       redactedColumnMap,
+        sampleFailedMap:         sampleFailedMap,
     }
 }
 
@@ -60,6 +148,31 @@ func (s *Service) GetTables() *Service {
         return s
     }
 
+    s.tableDefList = tableDefList
+    s.partitionDefMap = make(map[string][]database.TableDef)
+    for _, tb := range tableDefList {
+        if tb.PartitionOf != nil {
+            s.partitionDefMap[*tb.PartitionOf] = append(s.partitionDefMap[*tb.PartitionOf], tb)
+        }
+    }
+
+    return s
+}
+
+// HidePartitions removes the partitions from the tables that have been already retrieved. The partitions are still
+// listed under their partitioned table.
+func (s *Service) HidePartitions() *Service {
+    if s.err != nil {
+        return s
+    }
+
+    var tableDefList []database.TableDef
+    for _, tb := range s.tableDefList {
+        if tb.PartitionOf == nil {
+            tableDefList = append(tableDefList, tb)
+        }
+    }
+
     s.tableDefList = tableDefList
     return s
 }
@@ -140,127 +253,1183 @@ func (s *Service) GetGenericConstraintsOfAllTables() *Service {
     return s
 }
 
-// PrepareTemplateValues prepares and returns the template values based on the fetched information.
-func (s *Service) PrepareTemplateValues() (domain.TemplateValues, *pkg.Error) {
+// GetIndexesOfAllTables retrieves all the index details for all the tables that have been already retrieved.
+func (s *Service) GetIndexesOfAllTables() *Service {
     if s.err != nil {
-        return domain.TemplateValues{}, s.err
+        return s
     }
 
-    templateValues := domain.TemplateValues{DatabaseName: s.databaseName}
     for _, tb := range s.tableDefList {
-        var constraintsList []domain.ConstraintTmplValue
+        indexDefList, err := s.repo.GetIndexesOfTable(tb.TableName)
+        if err != nil {
+            s.err = err
+            return s
+        }
 
-        for _, pk := range s.primaryKeyDefMap[tb.TableName] {
-            constr := domain.ConstraintTmplValue{
-                Name:             pk.ConstraintName,
-                Type:             "PRIMARY KEY",
-                Column:           pk.ColumnName,
-                ReferencesTable:  "",
-                ReferencesColumn: "",
-            }
+        s.indexDefMap[tb.TableName] = indexDefList
+    }
 
-            constraintsList = append(constraintsList, constr)
-        }
+    return s
+}
 
-        for _, fk := range s.foreignKeyDefMap[tb.TableName] {
-            constr := domain.ConstraintTmplValue{
-                Name:             fk.ConstraintName,
-                Type:             "FOREIGN KEY",
-                Column:           fk.SourceColumnName,
-                ReferencesTable:  fk.ForeignTableName,
-                ReferencesColumn: fk.ForeignColumnName,
-            }
+// GetTriggersOfAllTables retrieves all the triggers for all the tables that have been already retrieved.
+func (s *Service) GetTriggersOfAllTables() *Service {
+    if s.err != nil {
+        return s
+    }
 
-            constraintsList = append(constraintsList, constr)
+    for _, tb := range s.tableDefList {
+        triggerDefList, err := s.repo.GetTriggersOfTable(tb.TableName)
+        if err != nil {
+            s.err = err
+            return s
         }
 
-        for _, gen := range s.genericConstraintDefMap[tb.TableName] {
-            constr := domain.ConstraintTmplValue{
-                Name:             gen.ConstraintName,
-                Type:             gen.ConstraintType,
-                Column:           gen.ColumnName,
-                ReferencesTable:  "",
-                ReferencesColumn: "",
-            }
+        s.triggerDefMap[tb.TableName] = triggerDefList
+    }
 
-            constraintsList = append(constraintsList, constr)
+    return s
+}
+
+// GetSecurityOfAllTables retrieves the granted privileges and the row level security policies for all the tables that
+// have been already retrieved.
+func (s *Service) GetSecurityOfAllTables() *Service {
+    if s.err != nil {
+        return s
+    }
+
+    for _, tb := range s.tableDefList {
+        grantDefList, err := s.repo.GetGrantsOfTable(tb.TableName)
+        if err != nil {
+            s.err = err
+            return s
         }
 
-        var columnList []domain.ColumnTmplValue
-        for _, col := range s.columnDefMap[tb.TableName] {
-            defaultVal := ""
-            if col.Default != nil {
-                defaultVal = *col.Default
-            }
+        policyDefList, err := s.repo.GetPoliciesOfTable(tb.TableName)
+        if err != nil {
+            s.err = err
+            return s
+        }
 
-            commentVal := ""
-            if col.Comment != nil {
-                commentVal = *col.Comment
-            }
+        s.grantDefMap[tb.TableName] = grantDefList
+        s.policyDefMap[tb.TableName] = policyDefList
+    }
 
-            colTmplVal := domain.ColumnTmplValue{
-                Ordinal:      col.OrdinalPosition,
-                Name:         col.ColumnName,
-                DataType:     strings.Replace(col.UDataType, "_", "", -1),
-                PK:           s.getPKValueForColumn(tb.TableName, col.ColumnName),
-                FK:           s.getFKValueForColumn(tb.TableName, col.ColumnName),
-                UQ:           s.getUQValueForColumn(tb.TableName, col.ColumnName),
-                NotNull:      col.IsNullable == "YES",
-                DefaultValue: defaultVal,
-                Comment:      commentVal,
-            }
+    return s
+}
 
-            columnList = append(columnList, colTmplVal)
-        }
+// GetRoutines retrieves the functions and procedures of the database. Their bodies are documented only when withBodies
+// is set.
+func (s *Service) GetRoutines(withBodies bool) *Service {
+    if s.err != nil {
+        return s
+    }
 
-        sort.Slice(columnList, func(i int, j int) bool {
-            return columnList[i].Ordinal < columnList[j].Ordinal
-        })
+    routineDefList, err := s.repo.GetRoutines()
+    if err != nil {
+        s.err = err
+        return s
+    }
 
-        sort.Slice(constraintsList, func(i int, j int) bool {
-            return constraintsList[i].Name < constraintsList[j].Name
-        })
+    s.routineDefList = routineDefList
+    s.routineBodies = withBodies
+    return s
+}
 
-        templateValues.TableList = append(templateValues.TableList, domain.TableTmplValue{
-            TableName:       tb.TableName,
-            ColumnList:      columnList,
-            ConstraintsList: constraintsList,
-        })
+// GetSequences retrieves the sequences of the database, along with the columns owning them.
+func (s *Service) GetSequences() *Service {
+    if s.err != nil {
+        return s
     }
 
-    sort.Slice(templateValues.TableList, func(i int, j int) bool {
-        return templateValues.TableList[i].TableName < templateValues.TableList[j].TableName
-    })
+    sequenceDefList, err := s.repo.GetSequences()
+    if err != nil {
+        s.err = err
+        return s
+    }
 
-    return templateValues, nil
+    s.sequenceDefList = sequenceDefList
+    return s
 }
 
-func (s *Service) getPKValueForColumn(tableName string, columnName string) bool {
-    for _, pk := range s.primaryKeyDefMap[tableName] {
-        if columnName == pk.ColumnName {
-            return true
+// GetViews retrieves the views and materialized views of the database, along with the tables and columns they depend
+// on.
+func (s *Service) GetViews() *Service {
+    if s.err != nil {
+        return s
+    }
+
+    viewDefList, err := s.repo.GetViews()
+    if err != nil {
+        s.err = err
+        return s
+    }
+
+    s.viewDefList = viewDefList
+    return s
+}
+
+// GetInheritance retrieves the parent/child inheritance relationships among the tables of the database.
+func (s *Service) GetInheritance() *Service {
+    if s.err != nil {
+        return s
+    }
+
+    inheritanceDefList, err := s.repo.GetInheritance()
+    if err != nil {
+        s.err = err
+        return s
+    }
+
+    s.inheritanceDefList = inheritanceDefList
+    return s
+}
+
+// GetDomains retrieves the domains of the database, along with their check constraints.
+func (s *Service) GetDomains() *Service {
+    if s.err != nil {
+        return s
+    }
+
+    domainDefList, err := s.repo.GetDomains()
+    if err != nil {
+        s.err = err
+        return s
+    }
+
+    s.domainDefList = domainDefList
+    return s
+}
+
+// GetCompositeTypes retrieves the composite types of the database, along with their attributes.
+func (s *Service) GetCompositeTypes() *Service {
+    if s.err != nil {
+        return s
+    }
+
+    compositeTypeDefList, err := s.repo.GetCompositeTypes()
+    if err != nil {
+        s.err = err
+        return s
+    }
+
+    s.compositeTypeDefList = compositeTypeDefList
+    return s
+}
+
+// GetStatisticsOfAllTables retrieves the planner statistics for all the tables, and their columns, that have been already
+// retrieved. The statistics are read from the catalog, thus none of the tables is scanned.
+func (s *Service) GetStatisticsOfAllTables() *Service {
+    if s.err != nil {
+        return s
+    }
+
+    for _, tb := range s.tableDefList {
+        tableStatistics, err := s.repo.GetStatisticsOfTable(tb.TableName)
+        if err != nil {
+            s.err = err
+            return s
+        }
+
+        columnStatisticsList, err := s.repo.GetColumnStatisticsOfTable(tb.TableName)
+        if err != nil {
+            s.err = err
+            return s
+        }
+
+        s.tableStatisticsMap[tb.TableName] = tableStatistics
+        s.columnStatisticsMap[tb.TableName] = make(map[string]database.ColumnStatisticsDef)
+        for _, colStats := range columnStatisticsList {
+            s.columnStatisticsMap[tb.TableName][colStats.ColumnName] = colStats
         }
     }
 
-    return false
+    s.profiled = true
+    return s
 }
 
-func (s *Service) getFKValueForColumn(tableName string, columnName string) bool {
-    for _, fk := range s.foreignKeyDefMap[tableName] {
-        if columnName == fk.SourceColumnName {
-            return true
+// InferRelationships proposes relationships for the tables that have been already retrieved based on the naming
+// conventions of their columns (i.e. 'customer_id' referencing 'customers.id'). A relationship is inferred only for
+// the columns which are not part of a foreign key and whose type matches the single column primary key of the
+// referenced table.
+func (s *Service) InferRelationships() *Service {
+    if s.err != nil {
+        return s
+    }
+
+    tableNames := make(map[string]bool)
+    for _, tb := range s.tableDefList {
+        tableNames[tb.TableName] = true
+    }
+
+    for _, tb := range s.tableDefList {
+        for _, col := range s.columnDefMap[tb.TableName] {
+            if !strings.HasSuffix(col.ColumnName, "_id") || len(col.ColumnName) <= len("_id") {
+                continue
+            }
+
+            if s.getFKValueForColumn(tb.TableName, col.ColumnName) {
+                continue
+            }
+
+            prefix := strings.TrimSuffix(col.ColumnName, "_id")
+            for _, candidate := range candidateTableNames(prefix) {
+                if !tableNames[candidate] {
+                    continue
+                }
+
+                pkColumn, ok := s.getSingleColumnPK(candidate)
+                if !ok || (candidate == tb.TableName && pkColumn.ColumnName == col.ColumnName) || pkColumn.UDataType != col.UDataType {
+                    continue
+                }
+
+                s.inferredForeignKeyMap[tb.TableName] = append(s.inferredForeignKeyMap[tb.TableName], database.FKConstraintDef{
+                    ConstraintName:    tb.TableName + "_" + col.ColumnName + "_inferred",
+                    SourceTableName:   tb.TableName,
+                    SourceColumnName:  col.ColumnName,
+                    ForeignTableName:  candidate,
+                    ForeignColumnName: pkColumn.ColumnName,
+                })
+                break
+            }
         }
     }
 
-    return false
+    return s
 }
 
-func (s *Service) getUQValueForColumn(tableName string, columnName string) bool {
-    for _, gen := range s.genericConstraintDefMap[tableName] {
-        if columnName == gen.ColumnName && gen.ConstraintType == "UNIQUE" {
-            return true
+// FocusOnTables keeps only the tables that have been already retrieved and are within the provided number of
+// relationship hops (in either direction) from the focus tables, along with the relationships among them. When
+// keysOnlyNeighbors is set, only the key columns (PK, FK, UQ) of the tables other than the focus ones are kept.
+func (s *Service) FocusOnTables(focusTables []string, depth int, keysOnlyNeighbors bool) *Service {
+    if s.err != nil {
+        return s
+    }
+
+    neighbors := make(map[string][]string)
+    for _, fkDefMap := range []map[string][]database.FKConstraintDef{s.foreignKeyDefMap, s.inferredForeignKeyMap} {
+        for tableName, fkDefList := range fkDefMap {
+            for _, fk := range fkDefList {
+                neighbors[tableName] = append(neighbors[tableName], fk.ForeignTableName)
+                neighbors[fk.ForeignTableName] = append(neighbors[fk.ForeignTableName], tableName)
+            }
         }
     }
 
-    return false
+    tableNames := make(map[string]bool)
+    for _, tb := range s.tableDefList {
+        tableNames[tb.TableName] = true
+    }
+
+    s.focusTableMap = make(map[string]bool)
+    keptTables := make(map[string]bool)
+    var frontier []string
+    for _, tableName := range focusTables {
+        if !tableNames[tableName] {
+            s.err = &pkg.Error{Err: fmt.Errorf("focus table '%v' does not exist", tableName)}
+            return s
+        }
+
+        s.focusTableMap[tableName] = true
+        keptTables[tableName] = true
+        frontier = append(frontier, tableName)
+    }
+
+    for hop := 0; hop < depth && len(frontier) > 0; hop++ {
+        var next []string
+        for _, tableName := range frontier {
+            for _, neighbor := range neighbors[tableName] {
+                if !keptTables[neighbor] && tableNames[neighbor] {
+                    keptTables[neighbor] = true
+                    next = append(next, neighbor)
+                }
+            }
+        }
+        frontier = next
+    }
+
+    var tableDefList []database.TableDef
+    for _, tb := range s.tableDefList {
+        if keptTables[tb.TableName] {
+            tableDefList = append(tableDefList, tb)
+        }
+    }
+    s.tableDefList = tableDefList
+
+    s.keysOnlyNeighbors = keysOnlyNeighbors
+    s.relatedObjectsOnly = true
+    return s
+}
+
+// KeepRelatedObjectsOnly keeps only the views, domains, composite types, routines and sequences that are related to
+// the tables that have been already retrieved, i.e. when some of the tables have been filtered out.
+func (s *Service) KeepRelatedObjectsOnly() *Service {
+    if s.err != nil {
+        return s
+    }
+
+    s.relatedObjectsOnly = true
+    return s
+}
+
+// TagTablesAndColumns assigns the tags of the provided rules to the tables and columns that have been already retrieved.
+func (s *Service) TagTablesAndColumns(tagRules []TagRule) *Service {
+    if s.err != nil {
+        return s
+    }
+
+    for _, tb := range s.tableDefList {
+        for _, rule := range tagRules {
+            if !rule.Tables.Match(tb.TableName) {
+                continue
+            }
+
+            if len(rule.Columns) == 0 {
+                s.tableTagMap[tb.TableName] = appendTags(s.tableTagMap[tb.TableName], rule.Tags...)
+                continue
+            }
+
+            for _, col := range s.columnDefMap[tb.TableName] {
+                if rule.Columns.Match(col.ColumnName) {
+                    s.addColumnTags(tb.TableName, col.ColumnName, rule.Tags...)
+                }
+            }
+        }
+    }
+
+    return s
+}
+
+// ClassifyColumnsByName tags the columns of the tables that have been already retrieved based on their names.
+func (s *Service) ClassifyColumnsByName(classifier classifier) *Service {
+    if s.err != nil {
+        return s
+    }
+
+    for _, tb := range s.tableDefList {
+        for _, col := range s.columnDefMap[tb.TableName] {
+            if tags := classifier.ClassifyByName(tb.TableName, col.ColumnName); len(tags) > 0 {
+                s.addColumnTags(tb.TableName, col.ColumnName, tags...)
+            }
+        }
+    }
+
+    return s
+}
+
+// ClassifyColumnsBySampleValues tags the columns of the tables that have been already retrieved based on their sample
+// values. Only the columns that have been sampled are classified, and the sample values of the columns that end up
+// with a redacted tag are discarded.
+func (s *Service) ClassifyColumnsBySampleValues(classifier classifier, sampleOpts SampleOptions) *Service {
+    if s.err != nil {
+        return s
+    }
+
+    for tableName, sampleValues := range s.sampleValueMap {
+        for columnName, values := range sampleValues {
+            if tags := classifier.ClassifyByValues(values); len(tags) > 0 {
+                s.addColumnTags(tableName, columnName, tags...)
+            }
+
+            if s.isRedacted(tableName, columnName, sampleOpts) {
+                delete(sampleValues, columnName)
+                s.redactedColumnMap[tableName][columnName] = true
+            }
+        }
+    }
+
+    return s
+}
+
+// GetSampleValuesOfAllTables retrieves a few example values for all the columns of the tables that have been already
+// retrieved, apart from the redacted ones. Columns that fail to be sampled (i.e. due to the timeout) are marked as such.
+func (s *Service) GetSampleValuesOfAllTables(sampleOpts SampleOptions) *Service {
+    if s.err != nil {
+        return s
+    }
+
+    for _, tb := range s.tableDefList {
+        s.sampleValueMap[tb.TableName] = make(map[string][]string)
+        s.redactedColumnMap[tb.TableName] = make(map[string]bool)
+        s.sampleFailedMap[tb.TableName] = make(map[string]bool)
+        for _, col := range s.columnDefMap[tb.TableName] {
+            if s.isRedacted(tb.TableName, col.ColumnName, sampleOpts) {
+                s.redactedColumnMap[tb.TableName][col.ColumnName] = true
+                continue
+            }
+
+            sampleValues, err := s.repo.GetSampleValuesOfColumn(tb.TableName, col.ColumnName, sampleOpts.Percentage, sampleOpts.Limit, sampleOpts.Timeout)
+            if err != nil {
+                err.LogWarning()
+                s.sampleFailedMap[tb.TableName][col.ColumnName] = true
+                continue
+            }
+
+            s.sampleValueMap[tb.TableName][col.ColumnName] = sampleValues
+        }
+    }
+
+    s.sampled = true
+    return s
+}
+
+// PrepareTemplateValues prepares and returns the template values based on the fetched information.
+func (s *Service) PrepareTemplateValues() (domain.TemplateValues, *pkg.Error) {
+    if s.err != nil {
+        return domain.TemplateValues{}, s.err
+    }
+
+    tableNames := make(map[string]bool)
+    for _, tb := range s.tableDefList {
+        tableNames[tb.TableName] = true
+    }
+
+    templateValues := domain.TemplateValues{DatabaseName: s.databaseName, Profiled: s.profiled, Sampled: s.sampled}
+
+    domainNames := make(map[string]bool)
+    for _, dom := range s.domainDefList {
+        domainNames[dom.DomainName] = true
+    }
+
+    compositeTypeNames := make(map[string]bool)
+    for _, ct := range s.compositeTypeDefList {
+        compositeTypeNames[ct.TypeName] = true
+    }
+
+    routineIDMap := make(map[int64]string)
+    routineNameCount := make(map[string]int)
+    for _, rt := range s.routineDefList {
+        routineNameCount[rt.RoutineName]++
+        routineIDMap[rt.RoutineOid] = "routine-" + rt.RoutineName
+        if routineNameCount[rt.RoutineName] > 1 {
+            routineIDMap[rt.RoutineOid] += fmt.Sprintf("-%d", routineNameCount[rt.RoutineName])
+        }
+    }
+
+    ownedSequenceMap := make(map[string]map[string]string)
+    for _, seq := range s.sequenceDefList {
+        seqTmplVal := domain.SequenceTmplValue{
+            Name:        seq.SequenceName,
+            DataType:    seq.DataType,
+            StartValue:  seq.StartValue,
+            IncrementBy: seq.IncrementBy,
+            MinValue:    seq.MinValue,
+            MaxValue:    seq.MaxValue,
+            Cycle:       seq.Cycle,
+        }
+
+        if seq.OwnedByTable != nil && seq.OwnedByColumn != nil {
+            if !tableNames[*seq.OwnedByTable] {
+                continue
+            }
+
+            if ownedSequenceMap[*seq.OwnedByTable] == nil {
+                ownedSequenceMap[*seq.OwnedByTable] = make(map[string]string)
+            }
+            ownedSequenceMap[*seq.OwnedByTable][*seq.OwnedByColumn] = seq.SequenceName
+
+            seqTmplVal.OwnedByTable = *seq.OwnedByTable
+            seqTmplVal.OwnedByColumn = *seq.OwnedByColumn
+        } else if s.relatedObjectsOnly {
+            continue
+        }
+
+        templateValues.SequenceList = append(templateValues.SequenceList, seqTmplVal)
+    }
+
+    for _, tb := range s.tableDefList {
+        var constraintsList []domain.ConstraintTmplValue
+
+        for _, pk := range s.primaryKeyDefMap[tb.TableName] {
+            constr := domain.ConstraintTmplValue{
+                Name:             pk.ConstraintName,
+                Type:             "PRIMARY KEY",
+                Column:           pk.ColumnName,
+                ReferencesTable:  "",
+                ReferencesColumn: "",
+            }
+
+            constraintsList = append(constraintsList, constr)
+        }
+
+        for _, fk := range s.foreignKeyDefMap[tb.TableName] {
+            if !tableNames[fk.ForeignTableName] {
+                continue
+            }
+
+            constr := domain.ConstraintTmplValue{
+                Name:             fk.ConstraintName,
+                Type:             "FOREIGN KEY",
+                Column:           fk.SourceColumnName,
+                ReferencesTable:  fk.ForeignTableName,
+                ReferencesColumn: fk.ForeignColumnName,
+            }
+
+            constraintsList = append(constraintsList, constr)
+        }
+
+        for _, fk := range s.inferredForeignKeyMap[tb.TableName] {
+            if !tableNames[fk.ForeignTableName] {
+                continue
+            }
+
+            constr := domain.ConstraintTmplValue{
+                Name:             fk.ConstraintName,
+                Type:             "FOREIGN KEY",
+                Column:           fk.SourceColumnName,
+                ReferencesTable:  fk.ForeignTableName,
+                ReferencesColumn: fk.ForeignColumnName,
+                Inferred:         true,
+            }
+
+            constraintsList = append(constraintsList, constr)
+        }
+
+        for _, gen := range s.genericConstraintDefMap[tb.TableName] {
+            constr := domain.ConstraintTmplValue{
+                Name:             gen.ConstraintName,
+                Type:             gen.ConstraintType,
+                Column:           gen.ColumnName,
+                ReferencesTable:  "",
+                ReferencesColumn: "",
+            }
+
+            constraintsList = append(constraintsList, constr)
+        }
+
+        var columnList []domain.ColumnTmplValue
+        for _, col := range s.columnDefMap[tb.TableName] {
+            defaultVal := ""
+            if col.Default != nil {
+                defaultVal = *col.Default
+            }
+
+            commentVal := ""
+            if col.Comment != nil {
+                commentVal = *col.Comment
+            }
+
+            identityVal := ""
+            if col.IsIdentity == "YES" && col.IdentityGeneration != nil {
+                identityVal = *col.IdentityGeneration
+            }
+
+            generatedVal := ""
+            if col.IsGenerated == "ALWAYS" && col.GenerationExpression != nil {
+                generatedVal = *col.GenerationExpression
+            }
+
+            domainVal := ""
+            if col.DomainName != nil && domainNames[*col.DomainName] {
+                domainVal = *col.DomainName
+            }
+
+            customTypeVal := ""
+            if col.DataType == "USER-DEFINED" && compositeTypeNames[col.UDataType] {
+                customTypeVal = col.UDataType
+            }
+
+            colTmplVal := domain.ColumnTmplValue{
+                Ordinal:      col.OrdinalPosition,
+                Name:         col.ColumnName,
+                DataType:     formatDataType(col),
+                RawDataType:  col.UDataType,
+                PK:           s.getPKValueForColumn(tb.TableName, col.ColumnName),
+                FK:           s.getFKValueForColumn(tb.TableName, col.ColumnName),
+                InferredFK:   s.getInferredFKValueForColumn(tb.TableName, col.ColumnName),
+                UQ:           s.getUQValueForColumn(tb.TableName, col.ColumnName),
+                NotNull:      col.IsNullable == "NO",
+                DefaultValue: defaultVal,
+                Identity:     identityVal,
+                Generated:    generatedVal,
+                Sequence:     ownedSequenceMap[tb.TableName][col.ColumnName],
+                Domain:       domainVal,
+                CustomType:   customTypeVal,
+                Comment:      commentVal,
+                Tags:         s.columnTagMap[tb.TableName][col.ColumnName],
+                Sensitivity:  getSensitivity(s.columnTagMap[tb.TableName][col.ColumnName]),
+            }
+
+            if s.keysOnlyNeighbors && !s.focusTableMap[tb.TableName] && !s.isKeyColumn(colTmplVal) {
+                continue
+            }
+
+            if s.profiled {
+                colTmplVal.Profile = s.getColumnProfile(tb.TableName, col.ColumnName)
+            }
+
+            if s.sampled {
+                colTmplVal.Examples = s.sampleValueMap[tb.TableName][col.ColumnName]
+                colTmplVal.ExamplesRedacted = s.redactedColumnMap[tb.TableName][col.ColumnName]
+                colTmplVal.ExamplesUnavailable = s.sampleFailedMap[tb.TableName][col.ColumnName]
+            }
+
+            columnList = append(columnList, colTmplVal)
+        }
+
+        var indexList []domain.IndexTmplValue
+        for _, idx := range s.indexDefMap[tb.TableName] {
+            var indexColumns []string
+            if idx.ColumnNames != "" {
+                indexColumns = strings.Split(idx.ColumnNames, ",")
+            }
+
+            var includeColumns []string
+            if idx.IncludeColumnNames != "" {
+                includeColumns = strings.Split(idx.IncludeColumnNames, ",")
+            }
+
+            indexList = append(indexList, domain.IndexTmplValue{
+                Name:           idx.IndexName,
+                Columns:        indexColumns,
+                IncludeColumns: includeColumns,
+                Method:         idx.Method,
+                Unique:         idx.IsUnique,
+                Primary:        idx.IsPrimary,
+                Partial:        idx.IsPartial,
+                Expression:     idx.HasExpressions,
+                Definition:     idx.Definition,
+            })
+        }
+
+        var triggerList []domain.TriggerTmplValue
+        for _, trg := range s.triggerDefMap[tb.TableName] {
+            triggerTmplVal := domain.TriggerTmplValue{
+                Name:       trg.TriggerName,
+                Timing:     trg.Timing,
+                Events:     strings.Split(trg.Events, ","),
+                Level:      trg.Level,
+                Enabled:    trg.Enabled,
+                Function:   trg.FunctionName,
+                RoutineID:  routineIDMap[trg.FunctionOid],
+                Definition: trg.Definition,
+            }
+
+            if trg.WhenCondition != nil {
+                triggerTmplVal.When = *trg.WhenCondition
+            }
+
+            triggerList = append(triggerList, triggerTmplVal)
+        }
+
+        var grantList []domain.GrantTmplValue
+        for _, grant := range s.grantDefMap[tb.TableName] {
+            grantTmplVal := domain.GrantTmplValue{
+                Grantee:   grant.Grantee,
+                Privilege: grant.PrivilegeType,
+                Grantable: grant.IsGrantable == "YES",
+            }
+
+            if grant.ColumnName != nil {
+                grantTmplVal.Column = *grant.ColumnName
+            }
+
+            grantList = append(grantList, grantTmplVal)
+        }
+
+        var policyList []domain.PolicyTmplValue
+        for _, policy := range s.policyDefMap[tb.TableName] {
+            policyTmplVal := domain.PolicyTmplValue{
+                Name:       policy.PolicyName,
+                Permissive: policy.Permissive,
+                Roles:      strings.Split(policy.Roles, ","),
+                Command:    policy.Command,
+            }
+
+            if policy.Using != nil {
+                policyTmplVal.Using = *policy.Using
+            }
+
+            if policy.WithCheck != nil {
+                policyTmplVal.WithCheck = *policy.WithCheck
+            }
+
+            policyList = append(policyList, policyTmplVal)
+        }
+
+        tableComment := ""
+        if tb.Comment != nil {
+            tableComment = *tb.Comment
+        }
+
+        estimatedRows := ""
+        if s.profiled {
+            estimatedRows = formatEstimatedRows(s.tableStatisticsMap[tb.TableName].EstimatedRows)
+        }
+
+        sort.Slice(columnList, func(i int, j int) bool {
+            return columnList[i].Ordinal < columnList[j].Ordinal
+        })
+
+        sort.Slice(constraintsList, func(i int, j int) bool {
+            return constraintsList[i].Name < constraintsList[j].Name
+        })
+
+        sort.Slice(indexList, func(i int, j int) bool {
+            return indexList[i].Name < indexList[j].Name
+        })
+
+        var partitionList []domain.PartitionTmplValue
+        for _, partition := range s.partitionDefMap[tb.TableName] {
+            partitionTmplVal := domain.PartitionTmplValue{Name: partition.TableName, Shown: tableNames[partition.TableName]}
+            if partition.PartitionBound != nil {
+                partitionTmplVal.Bound = *partition.PartitionBound
+            }
+
+            partitionList = append(partitionList, partitionTmplVal)
+        }
+
+        sort.Slice(partitionList, func(i int, j int) bool {
+            return partitionList[i].Name < partitionList[j].Name
+        })
+
+        var inheritsFrom, inheritedBy []string
+        for _, inh := range s.inheritanceDefList {
+            if inh.ChildTable == tb.TableName && tableNames[inh.ParentTable] {
+                inheritsFrom = append(inheritsFrom, inh.ParentTable)
+            }
+            if inh.ParentTable == tb.TableName && tableNames[inh.ChildTable] {
+                inheritedBy = append(inheritedBy, inh.ChildTable)
+            }
+        }
+
+        sort.Strings(inheritedBy)
+
+        var partitioning *domain.PartitioningTmplValue
+        if tb.PartitionStrategy != nil || tb.PartitionOf != nil {
+            partitioning = &domain.PartitioningTmplValue{}
+            if tb.PartitionStrategy != nil && tb.PartitionKey != nil {
+                partitioning.Strategy = *tb.PartitionStrategy
+                partitioning.Key = *tb.PartitionKey
+            }
+            if tb.PartitionOf != nil {
+                partitioning.PartitionOf = *tb.PartitionOf
+                partitioning.PartitionOfShown = tableNames[*tb.PartitionOf]
+            }
+            if tb.PartitionBound != nil {
+                partitioning.Bound = *tb.PartitionBound
+            }
+        }
+
+        subjectArea, subjectAreaIDVal := "", ""
+        if s.tableAreaMap != nil {
+            subjectArea = s.tableAreaMap[tb.TableName]
+            subjectAreaIDVal = subjectAreaID(subjectArea)
+        }
+
+        templateValues.TableList = append(templateValues.TableList, domain.TableTmplValue{
+            TableName:       tb.TableName,
+            Comment:         tableComment,
+            Tags:            s.tableTagMap[tb.TableName],
+            SubjectArea:     subjectArea,
+            SubjectAreaID:   subjectAreaIDVal,
+            EstimatedRows:   estimatedRows,
+            ColumnList:      columnList,
+            ConstraintsList: constraintsList,
+            IndexList:       indexList,
+            TriggerList:     triggerList,
+            RLSEnabled:      tb.RLSEnabled,
+            RLSForced:       tb.RLSForced,
+            GrantList:       grantList,
+            PolicyList:      policyList,
+            InheritsFrom:    inheritsFrom,
+            InheritedBy:     inheritedBy,
+            Partitioning:    partitioning,
+            PartitionList:   partitionList,
+        })
+    }
+
+    sort.Slice(templateValues.TableList, func(i int, j int) bool {
+        if s.tableAreaMap != nil {
+            left, right := s.subjectAreaPosition(templateValues.TableList[i].TableName), s.subjectAreaPosition(templateValues.TableList[j].TableName)
+            if left != right {
+                return left < right
+            }
+        }
+        return templateValues.TableList[i].TableName < templateValues.TableList[j].TableName
+    })
+
+    addIncomingReferences(templateValues.TableList)
+    templateValues.ViewList = s.prepareViews(templateValues.TableList)
+    templateValues.DomainList = s.prepareDomains(templateValues.TableList)
+    templateValues.CustomTypeList = s.prepareCustomTypes(templateValues.TableList)
+    templateValues.RoutineList = s.prepareRoutines(templateValues.TableList, routineIDMap)
+
+    if s.tableAreaMap != nil {
+        templateValues.SubjectAreaList, templateValues.AreaLinkList = s.prepareSubjectAreas(templateValues.TableList)
+    }
+
+    return templateValues, nil
+}
+
+// prepareViews returns the views of the database along with the tables (or views) they depend on, and assigns to each
+// table the views that depend on it. When keeping the related objects only, only the views that depend on the
+// documented tables are returned.
+func (s *Service) prepareViews(tableList []domain.TableTmplValue) []domain.ViewTmplValue {
+    tablePosition := make(map[string]int)
+    for i, tb := range tableList {
+        tablePosition[tb.TableName] = i
+    }
+
+    var viewList []domain.ViewTmplValue
+    for _, vw := range s.viewDefList {
+        viewTmplVal := domain.ViewTmplValue{Name: vw.ViewName, Kind: vw.Kind}
+        if vw.Comment != nil {
+            viewTmplVal.Comment = *vw.Comment
+        }
+
+        dependencyPosition := make(map[string]int)
+        dependsOnDocumented := false
+        for _, dep := range vw.Dependencies {
+            _, isDocumentedTable := tablePosition[dep.TableName]
+            dependsOnDocumented = dependsOnDocumented || isDocumentedTable
+
+            pos, ok := dependencyPosition[dep.TableName]
+            if !ok {
+                pos = len(viewTmplVal.DependsOn)
+                dependencyPosition[dep.TableName] = pos
+                viewTmplVal.DependsOn = append(viewTmplVal.DependsOn, domain.ViewDependencyTmplValue{
+                    Name:  dep.TableName,
+                    Kind:  dep.TableKind,
+                    Shown: isDocumentedTable,
+                })
+            }
+
+            if dep.ColumnName != nil {
+                viewTmplVal.DependsOn[pos].Columns = append(viewTmplVal.DependsOn[pos].Columns, *dep.ColumnName)
+            }
+        }
+
+        if s.relatedObjectsOnly && !dependsOnDocumented {
+            continue
+        }
+
+        viewList = append(viewList, viewTmplVal)
+    }
+
+    viewNames := make(map[string]bool)
+    for _, vw := range viewList {
+        viewNames[vw.Name] = true
+    }
+
+    for _, vw := range viewList {
+        for i, dep := range vw.DependsOn {
+            if pos, ok := tablePosition[dep.Name]; ok {
+                tableList[pos].UsedByViews = append(tableList[pos].UsedByViews, vw.Name)
+            } else if viewNames[dep.Name] {
+                vw.DependsOn[i].Shown = true
+            }
+        }
+    }
+
+    return viewList
+}
+
+// prepareDomains returns the domains of the database along with their check constraints. When keeping the related
+// objects only, only the domains used by the columns of the documented tables are returned.
+func (s *Service) prepareDomains(tableList []domain.TableTmplValue) []domain.DomainTmplValue {
+    usedDomains := make(map[string]bool)
+    for _, tb := range tableList {
+        for _, col := range tb.ColumnList {
+            if col.Domain != "" {
+                usedDomains[col.Domain] = true
+            }
+        }
+    }
+
+    var domainList []domain.DomainTmplValue
+    for _, dom := range s.domainDefList {
+        if s.relatedObjectsOnly && !usedDomains[dom.DomainName] {
+            continue
+        }
+
+        domTmplVal := domain.DomainTmplValue{
+            Name:     dom.DomainName,
+            BaseType: dom.BaseType,
+            NotNull:  dom.NotNull,
+        }
+
+        if dom.Default != nil {
+            domTmplVal.DefaultValue = *dom.Default
+        }
+
+        if dom.Comment != nil {
+            domTmplVal.Comment = *dom.Comment
+        }
+
+        for _, constr := range dom.Constraints {
+            domTmplVal.CheckList = append(domTmplVal.CheckList, domain.DomainCheckTmplValue{
+                Name:       constr.ConstraintName,
+                Definition: constr.Definition,
+            })
+        }
+
+        domainList = append(domainList, domTmplVal)
+    }
+
+    return domainList
+}
+
+// prepareCustomTypes returns the composite types of the database along with their attributes. When keeping the related
+// objects only, only the composite types used by the columns of the documented tables are returned.
+func (s *Service) prepareCustomTypes(tableList []domain.TableTmplValue) []domain.CustomTypeTmplValue {
+    usedCustomTypes := make(map[string]bool)
+    for _, tb := range tableList {
+        for _, col := range tb.ColumnList {
+            if col.CustomType != "" {
+                usedCustomTypes[col.CustomType] = true
+            }
+        }
+    }
+
+    var customTypeList []domain.CustomTypeTmplValue
+    for _, ct := range s.compositeTypeDefList {
+        if s.relatedObjectsOnly && !usedCustomTypes[ct.TypeName] {
+            continue
+        }
+
+        ctTmplVal := domain.CustomTypeTmplValue{Name: ct.TypeName}
+        if ct.Comment != nil {
+            ctTmplVal.Comment = *ct.Comment
+        }
+
+        for _, attr := range ct.Attributes {
+            ctTmplVal.AttributeList = append(ctTmplVal.AttributeList, domain.CustomAttributeTmplValue{
+                Ordinal:  attr.OrdinalPosition,
+                Name:     attr.AttributeName,
+                DataType: attr.DataType,
+            })
+        }
+
+        customTypeList = append(customTypeList, ctTmplVal)
+    }
+
+    return customTypeList
+}
+
+// prepareRoutines returns the functions and procedures of the database, identified by the provided ids. When keeping
+// the related objects only, only the routines called by the triggers of the documented tables are returned.
+func (s *Service) prepareRoutines(tableList []domain.TableTmplValue, routineIDMap map[int64]string) []domain.RoutineTmplValue {
+    usedRoutines := make(map[string]bool)
+    for _, tb := range tableList {
+        for _, trg := range tb.TriggerList {
+            if trg.RoutineID != "" {
+                usedRoutines[trg.RoutineID] = true
+            }
+        }
+    }
+
+    var routineList []domain.RoutineTmplValue
+    for _, rt := range s.routineDefList {
+        if s.relatedObjectsOnly && !usedRoutines[routineIDMap[rt.RoutineOid]] {
+            continue
+        }
+
+        rtTmplVal := domain.RoutineTmplValue{
+            ID:              routineIDMap[rt.RoutineOid],
+            Name:            rt.RoutineName,
+            Signature:       rt.Signature,
+            Kind:            rt.Kind,
+            Language:        rt.Language,
+            Volatility:      rt.Volatility,
+            SecurityDefiner: rt.SecurityDefiner,
+        }
+
+        if rt.ReturnType != nil {
+            rtTmplVal.ReturnType = *rt.ReturnType
+        }
+
+        if rt.Comment != nil {
+            rtTmplVal.Comment = *rt.Comment
+        }
+
+        if s.routineBodies && rt.Body != nil {
+            rtTmplVal.Body = *rt.Body
+        }
+
+        routineList = append(routineList, rtTmplVal)
+    }
+
+    return routineList
+}
+
+// addIncomingReferences assigns to each table the (declared or inferred) foreign key columns of the tables that
+// reference it, sorted by the referencing table, constraint and column.
+func addIncomingReferences(tableList []domain.TableTmplValue) {
+    tablePosition := make(map[string]int)
+    for i, tb := range tableList {
+        tablePosition[tb.TableName] = i
+    }
+
+    for _, tb := range tableList {
+        for _, constr := range tb.ConstraintsList {
+            pos, ok := tablePosition[constr.ReferencesTable]
+            if constr.ReferencesTable == "" || !ok {
+                continue
+            }
+
+            tableList[pos].ReferencedBy = append(tableList[pos].ReferencedBy, domain.ReferenceTmplValue{
+                Name:             constr.Name,
+                Table:            tb.TableName,
+                Column:           constr.Column,
+                ReferencedColumn: constr.ReferencesColumn,
+                Inferred:         constr.Inferred,
+            })
+        }
+    }
+
+    for _, tb := range tableList {
+        referencedBy := tb.ReferencedBy
+        sort.SliceStable(referencedBy, func(i int, j int) bool {
+            if referencedBy[i].Table != referencedBy[j].Table {
+                return referencedBy[i].Table < referencedBy[j].Table
+            }
+            return referencedBy[i].Name < referencedBy[j].Name
+        })
+    }
+}
+
+// formatDataType returns the full type of the column (including its length, precision, array dimensions or domain) and
+// falls back to the underlying type name when the full type is not available.
+func formatDataType(col database.ColumnDef) string {
+    if col.FormattedType != nil && *col.FormattedType != "" {
+        return *col.FormattedType
+    }
+
+    if col.DataType == "ARRAY" {
+        return strings.TrimPrefix(col.UDataType, "_") + "[]"
+    }
+
+    return col.UDataType
+}
+
+func (s *Service) getPKValueForColumn(tableName string, columnName string) bool {
+    for _, pk := range s.primaryKeyDefMap[tableName] {
+        if columnName == pk.ColumnName {
+            return true
+        }
+    }
+
+    return false
+}
+
+func (s *Service) getFKValueForColumn(tableName string, columnName string) bool {
+    for _, fk := range s.foreignKeyDefMap[tableName] {
+        if columnName == fk.SourceColumnName {
+            return true
+        }
+    }
+
+    return false
+}
+
+func (s *Service) getUQValueForColumn(tableName string, columnName string) bool {
+    for _, gen := range s.genericConstraintDefMap[tableName] {
+        if columnName == gen.ColumnName && gen.ConstraintType == "UNIQUE" {
+            return true
+        }
+    }
+
+    return false
+}
+
+// isKeyColumn checks whether the column is part of a primary, foreign (declared or inferred) or unique key.
+func (s *Service) isKeyColumn(col domain.ColumnTmplValue) bool {
+    return col.PK || col.FK || col.InferredFK || col.UQ
+}
+
+// getInferredFKValueForColumn checks whether a relationship has been inferred for the column of the table.
+func (s *Service) getInferredFKValueForColumn(tableName string, columnName string) bool {
+    for _, fk := range s.inferredForeignKeyMap[tableName] {
+        if fk.SourceColumnName == columnName {
+            return true
+        }
+    }
+
+    return false
+}
+
+// getSingleColumnPK returns the column definition of the primary key of a table, as long as it consists of one column.
+func (s *Service) getSingleColumnPK(tableName string) (database.ColumnDef, bool) {
+    if len(s.primaryKeyDefMap[tableName]) != 1 {
+        return database.ColumnDef{}, false
+    }
+
+    for _, col := range s.columnDefMap[tableName] {
+        if col.ColumnName == s.primaryKeyDefMap[tableName][0].ColumnName {
+            return col, true
+        }
+    }
+
+    return database.ColumnDef{}, false
+}
+
+// candidateTableNames returns the possible table names (singular and plural forms) for the prefix of a column.
+func candidateTableNames(prefix string) []string {
+    candidates := []string{prefix, prefix + "s", prefix + "es"}
+    if strings.HasSuffix(prefix, "y") {
+        candidates = append(candidates, strings.TrimSuffix(prefix, "y")+"ies")
+    }
+
+    return candidates
+}
+
+// addColumnTags assigns the provided tags to a column, skipping the ones that are already assigned.
+func (s *Service) addColumnTags(tableName string, columnName string, tags ...string) {
+    if _, ok := s.columnTagMap[tableName]; !ok {
+        s.columnTagMap[tableName] = make(map[string][]string)
+    }
+
+    s.columnTagMap[tableName][columnName] = appendTags(s.columnTagMap[tableName][columnName], tags...)
+}
+
+// isRedacted checks whether the column must not be sampled based on its name or its tags.
+func (s *Service) isRedacted(tableName string, columnName string, sampleOpts SampleOptions) bool {
+    if sampleOpts.RedactColumns.Match(columnName) || sampleOpts.RedactColumns.Match(tableName+"."+columnName) {
+        return true
+    }
+
+    for _, tag := range s.columnTagMap[tableName][columnName] {
+        for _, redactTag := range sampleOpts.RedactTags {
+            if tag == redactTag {
+                return true
+            }
+        }
+    }
+
+    return false
+}
+
+// getSensitivity returns the highest sensitivity ("pii" over "sensitive") of the provided tags.
+func getSensitivity(tags []string) string {
+    sensitivity := ""
+    for _, tag := range tags {
+        switch tag {
+        case "pii":
+            return tag
+        case "sensitive":
+            sensitivity = tag
+        }
+    }
+
+    return sensitivity
+}
+
+// appendTags appends the provided tags to the list, skipping the ones that already exist.
+func appendTags(tagList []string, tags ...string) []string {
+    for _, tag := range tags {
+        exists := false
+        for _, existing := range tagList {
+            if existing == tag {
+                exists = true
+                break
+            }
+        }
+
+        if !exists {
+            tagList = append(tagList, tag)
+        }
+    }
+
+    return tagList
 }
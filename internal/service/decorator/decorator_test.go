@@ -0,0 +1,233 @@
+package decorator
+
+import (
+    "fmt"
+    "reflect"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/eujoy/data-dict/internal/model/database"
+    "github.com/eujoy/data-dict/internal/model/domain"
+    "github.com/eujoy/data-dict/internal/service/integrity"
+    "github.com/eujoy/data-dict/pkg"
+)
+
+// fakeClassifier tags the columns whose sample values look like emails.
+type fakeClassifier struct{}
+
+// ClassifyByName does not tag any column by its name.
+func (c fakeClassifier) ClassifyByName(tableName string, columnName string) []string {
+    return nil
+}
+
+// ClassifyByValues tags the values that contain an '@' as pii emails.
+func (c fakeClassifier) ClassifyByValues(values []string) []string {
+    for _, value := range values {
+        if !strings.Contains(value, "@") {
+            return nil
+        }
+    }
+
+    return []string{"pii", "email"}
+}
+
+// fakeSampleRepo returns the configured sample values of the columns and fails for the rest of them. The rest of the
+// repository methods are not implemented.
+type fakeSampleRepo struct {
+    repo
+    sampleValues map[string][]string
+}
+
+// GetSampleValuesOfColumn returns the configured sample values of the column.
+func (r fakeSampleRepo) GetSampleValuesOfColumn(tableName string, columnName string, percentage float64, limit int, timeout time.Duration) ([]string, *pkg.Error) {
+    values, ok := r.sampleValues[columnName]
+    if !ok {
+        return nil, &pkg.Error{Err: fmt.Errorf("canceling statement due to statement timeout")}
+    }
+
+    return values, nil
+}
+
+// findColumn returns the template value of the column of the table.
+func findColumn(t *testing.T, templateValues domain.TemplateValues, tableName string, columnName string) domain.ColumnTmplValue {
+    for _, tb := range templateValues.TableList {
+        if tb.TableName != tableName {
+            continue
+        }
+
+        for _, col := range tb.ColumnList {
+            if col.Name == columnName {
+                return col
+            }
+        }
+    }
+
+    t.Fatalf("column %v.%v not found", tableName, columnName)
+    return domain.ColumnTmplValue{}
+}
+
+func TestSampleAndClassifyColumns(t *testing.T) {
+    testCases := map[string]struct {
+        redactTags          []string
+        column              string
+        expectedExamples    []string
+        expectedRedacted    bool
+        expectedUnavailable bool
+    }{
+        "redacted tag discards the sample values": {
+            redactTags:       []string{"pii"},
+            column:           "contact",
+            expectedExamples: nil,
+            expectedRedacted: true,
+        },
+        "not redacted tag keeps the sample values": {
+            redactTags:       []string{"sensitive"},
+            column:           "contact",
+            expectedExamples: []string{"a@example.com", "b@example.com"},
+            expectedRedacted: false,
+        },
+        "untagged column keeps the sample values": {
+            redactTags:       []string{"pii"},
+            column:           "name",
+            expectedExamples: []string{"alice", "bob"},
+            expectedRedacted: false,
+        },
+        "redacted column name is never sampled": {
+            redactTags:       []string{"pii"},
+            column:           "password",
+            expectedExamples: nil,
+            expectedRedacted: true,
+        },
+        "failed sampling is unavailable instead of redacted": {
+            redactTags:          []string{"pii"},
+            column:              "token",
+            expectedExamples:    nil,
+            expectedRedacted:    false,
+            expectedUnavailable: true,
+        },
+    }
+
+    redactColumns, patternErr := pkg.NewPatterns([]string{"*password*"})
+    if patternErr != nil {
+        t.Fatalf("unexpected error: %v", patternErr.Err)
+    }
+
+    for name, tc := range testCases {
+        t.Run(name, func(t *testing.T) {
+            repo := fakeSampleRepo{
+                sampleValues: map[string][]string{
+                    "name":     {"alice", "bob"},
+                    "contact":  {"a@example.com", "b@example.com"},
+                    "password": {"hunter2"},
+                },
+            }
+
+            s := New(repo, "db")
+            s.tableDefList = []database.TableDef{{TableName: "users"}}
+            s.columnDefMap["users"] = []database.ColumnDef{
+                {OrdinalPosition: 1, ColumnName: "name", DataType: "text"},
+                {OrdinalPosition: 2, ColumnName: "contact", DataType: "text"},
+                {OrdinalPosition: 3, ColumnName: "password", DataType: "text"},
+                {OrdinalPosition: 4, ColumnName: "token", DataType: "text"},
+            }
+
+            sampleOpts := SampleOptions{Limit: 2, RedactColumns: redactColumns, RedactTags: tc.redactTags}
+            templateValues, err := s.GetSampleValuesOfAllTables(sampleOpts).
+                ClassifyColumnsBySampleValues(fakeClassifier{}, sampleOpts).
+                PrepareTemplateValues()
+            if err != nil {
+                t.Fatalf("unexpected error: %v", err.Err)
+            }
+
+            col := findColumn(t, templateValues, "users", tc.column)
+            if !reflect.DeepEqual(col.Examples, tc.expectedExamples) {
+                t.Errorf("expected examples %v, got %v", tc.expectedExamples, col.Examples)
+            }
+            if col.ExamplesRedacted != tc.expectedRedacted {
+                t.Errorf("expected examples redacted %v, got %v", tc.expectedRedacted, col.ExamplesRedacted)
+            }
+            if col.ExamplesUnavailable != tc.expectedUnavailable {
+                t.Errorf("expected examples unavailable %v, got %v", tc.expectedUnavailable, col.ExamplesUnavailable)
+            }
+        })
+    }
+}
+
+func TestPrepareTemplateValuesDropsReferencesToMissingTables(t *testing.T) {
+    testCases := map[string]struct {
+        relatedObjectsOnly bool
+        expectedDomains    int
+    }{
+        "all the objects are kept when no table is filtered out": {
+            relatedObjectsOnly: false,
+            expectedDomains:    1,
+        },
+        "only the related objects are kept when tables are filtered out": {
+            relatedObjectsOnly: true,
+            expectedDomains:    0,
+        },
+    }
+
+    for name, tc := range testCases {
+        t.Run(name, func(t *testing.T) {
+            s := New(nil, "db")
+            s.tableDefList = []database.TableDef{{TableName: "orders"}}
+            s.columnDefMap["orders"] = []database.ColumnDef{
+                {OrdinalPosition: 1, ColumnName: "id", DataType: "integer"},
+                {OrdinalPosition: 2, ColumnName: "customer_id", DataType: "integer"},
+            }
+            s.foreignKeyDefMap["orders"] = []database.FKConstraintDef{
+                {ConstraintName: "orders_customer_id_fkey", SourceTableName: "orders", SourceColumnName: "customer_id", ForeignTableName: "customers", ForeignColumnName: "id"},
+            }
+            s.domainDefList = []database.DomainDef{{DomainName: "email", BaseType: "text"}}
+            if tc.relatedObjectsOnly {
+                s.KeepRelatedObjectsOnly()
+            }
+
+            templateValues, err := s.PrepareTemplateValues()
+            if err != nil {
+                t.Fatalf("unexpected error: %v", err.Err)
+            }
+
+            for _, constr := range templateValues.TableList[0].ConstraintsList {
+                if constr.ReferencesTable == "customers" {
+                    t.Errorf("expected the foreign key to the missing table to be dropped, got %v", constr.Name)
+                }
+            }
+            if len(templateValues.DomainList) != tc.expectedDomains {
+                t.Errorf("expected %v domains, got %v", tc.expectedDomains, len(templateValues.DomainList))
+            }
+        })
+    }
+}
+
+func TestPrepareTemplateValuesCompositeForeignKey(t *testing.T) {
+    s := New(nil, "db")
+    s.tableDefList = []database.TableDef{{TableName: "orders"}, {TableName: "order_lines"}}
+    s.columnDefMap["orders"] = []database.ColumnDef{
+        {OrdinalPosition: 1, ColumnName: "tenant_id", DataType: "integer"},
+        {OrdinalPosition: 2, ColumnName: "id", DataType: "integer"},
+    }
+    s.columnDefMap["order_lines"] = []database.ColumnDef{
+        {OrdinalPosition: 1, ColumnName: "order_id", DataType: "integer"},
+        {OrdinalPosition: 2, ColumnName: "tenant_id", DataType: "integer"},
+    }
+    // The repository returns one row per column pair of the key, in the order of the key.
+    s.foreignKeyDefMap["order_lines"] = []database.FKConstraintDef{
+        {ConstraintName: "order_lines_order_fkey", SourceTableName: "order_lines", SourceColumnName: "tenant_id", ForeignTableName: "orders", ForeignColumnName: "tenant_id"},
+        {ConstraintName: "order_lines_order_fkey", SourceTableName: "order_lines", SourceColumnName: "order_id", ForeignTableName: "orders", ForeignColumnName: "id"},
+    }
+
+    templateValues, err := s.PrepareTemplateValues()
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err.Err)
+    }
+
+    expected := []integrity.Relationship{
+        {Name: "order_lines_order_fkey", ChildTable: "order_lines", ChildColumns: []string{"tenant_id", "order_id"}, ParentTable: "orders", ParentColumns: []string{"tenant_id", "id"}},
+    }
+    if actual := integrity.Relationships(templateValues); !reflect.DeepEqual(actual, expected) {
+        t.Errorf("expected relationships %+v, got %+v", expected, actual)
+    }
+}
@@ -0,0 +1,144 @@
+package decorator
+
+import (
+    "fmt"
+    "math"
+    "strconv"
+    "strings"
+
+    "github.com/eujoy/data-dict/internal/model/domain"
+)
+
+const (
+    maxMostCommonValues = 10
+    maxHistogramBounds  = 11
+)
+
+// getColumnProfile prepares the profile of a column based on the retrieved planner statistics.
+func (s *Service) getColumnProfile(tableName string, columnName string) *domain.ColumnProfileTmplValue {
+    colStats, ok := s.columnStatisticsMap[tableName][columnName]
+    if !ok {
+        return nil
+    }
+
+    profile := &domain.ColumnProfileTmplValue{
+        NullFraction:   fmt.Sprintf("%.2f%%", colStats.NullFraction*100),
+        DistinctValues: formatDistinctValues(colStats.DistinctValues, s.tableStatisticsMap[tableName].EstimatedRows),
+    }
+
+    if colStats.MostCommonValues != nil {
+        values := parseArrayText(*colStats.MostCommonValues)
+
+        var frequencies []string
+        if colStats.MostCommonFrequencies != nil {
+            frequencies = parseArrayText(*colStats.MostCommonFrequencies)
+        }
+
+        var mostCommon []string
+        for i, val := range values {
+            if i == maxMostCommonValues {
+                break
+            }
+
+            if i < len(frequencies) {
+                if freq, err := strconv.ParseFloat(frequencies[i], 64); err == nil {
+                    val = fmt.Sprintf("%v (%.2f%%)", val, freq*100)
+                }
+            }
+
+            mostCommon = append(mostCommon, val)
+        }
+
+        profile.MostCommonValues = strings.Join(mostCommon, ", ")
+    }
+
+    if colStats.HistogramBounds != nil {
+        profile.HistogramBounds = strings.Join(sampleBounds(parseArrayText(*colStats.HistogramBounds)), ", ")
+    }
+
+    return profile
+}
+
+// formatEstimatedRows formats the estimated number of rows of a table, as long as the table has been analyzed.
+func formatEstimatedRows(estimatedRows float64) string {
+    if estimatedRows < 0 {
+        return "unknown (not analyzed)"
+    }
+
+    return fmt.Sprintf("%.0f", estimatedRows)
+}
+
+// formatDistinctValues formats the estimated number of distinct values of a column. Negative values of n_distinct
+// describe the (negated) ratio of distinct values to the number of rows.
+func formatDistinctValues(distinctValues float64, estimatedRows float64) string {
+    if distinctValues >= 0 {
+        return fmt.Sprintf("%.0f", distinctValues)
+    }
+
+    ratio := -distinctValues * 100
+    if estimatedRows <= 0 {
+        return fmt.Sprintf("%.1f%% of rows", ratio)
+    }
+
+    return fmt.Sprintf("%.0f (%.1f%% of rows)", math.Round(-distinctValues*estimatedRows), ratio)
+}
+
+// sampleBounds keeps a limited number of evenly distributed bounds of a histogram, including the first and last ones.
+func sampleBounds(bounds []string) []string {
+    if len(bounds) <= maxHistogramBounds {
+        return bounds
+    }
+
+    var sampled []string
+    for i := 0; i < maxHistogramBounds; i++ {
+        pos := i * (len(bounds) - 1) / (maxHistogramBounds - 1)
+        sampled = append(sampled, bounds[pos])
+    }
+
+    return sampled
+}
+
+// parseArrayText parses the text representation of a one dimensional postgres array to its elements.
+func parseArrayText(arrayText string) []string {
+    arrayText = strings.TrimSpace(arrayText)
+    if len(arrayText) < 2 || arrayText[0] != '{' || arrayText[len(arrayText)-1] != '}' {
+        return nil
+    }
+
+    var elements []string
+    var current strings.Builder
+    inQuotes, escaped, quoted := false, false, false
+    for _, ch := range arrayText[1 : len(arrayText)-1] {
+        switch {
+        case escaped:
+            current.WriteRune(ch)
+            escaped = false
+        case ch == '\\' && inQuotes:
+            escaped = true
+        case ch == '"':
+            inQuotes = !inQuotes
+            quoted = true
+        case ch == ',' && !inQuotes:
+            elements = append(elements, arrayElement(current.String(), quoted))
+            current.Reset()
+            quoted = false
+        default:
+            current.WriteRune(ch)
+        }
+    }
+
+    if current.Len() > 0 || quoted || len(elements) > 0 {
+        elements = append(elements, arrayElement(current.String(), quoted))
+    }
+
+    return elements
+}
+
+// arrayElement converts the unquoted NULL elements of an array to their readable representation.
+func arrayElement(element string, quoted bool) string {
+    if !quoted && element == "NULL" {
+        return "(null)"
+    }
+
+    return element
+}
@@ -0,0 +1,148 @@
+package integrity
+
+import (
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/eujoy/data-dict/internal/model/domain"
+    "github.com/eujoy/data-dict/pkg"
+)
+
+type repo interface {
+    CountOrphanRows(childTable string, childColumns []string, parentTable string, parentColumns []string, limit int, timeout time.Duration) (int, *pkg.Error)
+}
+
+// Relationship describes a declared or inferred relationship between a child and a parent table.
+type Relationship struct {
+    Name          string
+    ChildTable    string
+    ChildColumns  []string
+    ParentTable   string
+    ParentColumns []string
+    Inferred      bool
+}
+
+// Result describes the outcome of the integrity check of a relationship.
+type Result struct {
+    Relationship Relationship
+    OrphanRows   int
+    Limited      bool
+    Err          *pkg.Error
+}
+
+// Service describes the integrity service which checks the relationships for orphan rows.
+type Service struct {
+    repo    repo
+    limit   int
+    timeout time.Duration
+}
+
+// New creates and returns a new integrity service. The orphan rows of each relationship are counted up to the
+// provided limit and each check is cancelled when it exceeds the provided timeout.
+func New(repo repo, limit int, timeout time.Duration) *Service {
+    return &Service{
+        repo:    repo,
+        limit:   limit,
+        timeout: timeout,
+    }
+}
+
+// Check counts the orphan rows for each declared or inferred relationship of the template values.
+func (s *Service) Check(templateValues domain.TemplateValues) []Result {
+    var results []Result
+    for _, rel := range Relationships(templateValues) {
+        orphanRows, err := s.repo.CountOrphanRows(rel.ChildTable, rel.ChildColumns, rel.ParentTable, rel.ParentColumns, s.limit, s.timeout)
+        results = append(results, Result{
+            Relationship: rel,
+            OrphanRows:   orphanRows,
+            Limited:      err == nil && orphanRows >= s.limit,
+            Err:          err,
+        })
+    }
+
+    return results
+}
+
+// Relationships groups the (possibly multi column) foreign key constraints of the tables to relationships.
+func Relationships(templateValues domain.TemplateValues) []Relationship {
+    var relationships []Relationship
+    for _, tb := range templateValues.TableList {
+        relPosition := make(map[string]int)
+        for _, constr := range tb.ConstraintsList {
+            if constr.ReferencesTable == "" {
+                continue
+            }
+
+            pos, ok := relPosition[constr.Name]
+            if !ok {
+                pos = len(relationships)
+                relPosition[constr.Name] = pos
+                relationships = append(relationships, Relationship{
+                    Name:        constr.Name,
+                    ChildTable:  tb.TableName,
+                    ParentTable: constr.ReferencesTable,
+                    Inferred:    constr.Inferred,
+                })
+            }
+
+            relationships[pos].ChildColumns = append(relationships[pos].ChildColumns, constr.Column)
+            relationships[pos].ParentColumns = append(relationships[pos].ParentColumns, constr.ReferencesColumn)
+        }
+    }
+
+    return relationships
+}
+
+// Render formats the results as a text report with one line per relationship followed by a summary.
+func Render(results []Result) string {
+    var sb strings.Builder
+    var orphaned, failed int
+    for _, res := range results {
+        rel := res.Relationship
+        description := fmt.Sprintf(
+            "%v(%v) -> %v(%v)",
+            rel.ChildTable,
+            strings.Join(rel.ChildColumns, ", "),
+            rel.ParentTable,
+            strings.Join(rel.ParentColumns, ", "),
+        )
+        if rel.Inferred {
+            description += " [inferred]"
+        }
+
+        switch {
+        case res.Err != nil:
+            failed++
+            sb.WriteString(fmt.Sprintf("%-8v %v: %v\n", "FAILED", description, res.Err.Err))
+        case res.OrphanRows == 0:
+            sb.WriteString(fmt.Sprintf("%-8v %v: no orphan rows\n", "OK", description))
+        case res.Limited:
+            orphaned++
+            sb.WriteString(fmt.Sprintf("%-8v %v: at least %d orphan row(s)\n", "ORPHANS", description, res.OrphanRows))
+        default:
+            orphaned++
+            sb.WriteString(fmt.Sprintf("%-8v %v: %d orphan row(s)\n", "ORPHANS", description, res.OrphanRows))
+        }
+    }
+
+    sb.WriteString(fmt.Sprintf(
+        "\n%d relationship(s) checked: %d with orphan rows, %d failed\n",
+        len(results),
+        orphaned,
+        failed,
+    ))
+
+    return sb.String()
+}
+
+// HasIssues checks whether any of the relationships has orphan rows or could not be checked.
+func HasIssues(results []Result) bool {
+    for _, res := range results {
+        if res.Err != nil || res.OrphanRows > 0 {
+            return true
+        }
+    }
+
+    return false
+}
@@ -0,0 +1,132 @@
+package integrity
+
+import (
+    "fmt"
+    "reflect"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/eujoy/data-dict/internal/model/domain"
+    "github.com/eujoy/data-dict/pkg"
+)
+
+// fakeRepo returns the orphan rows or the error configured for each child table.
+type fakeRepo struct {
+    orphanRows map[string]int
+    errs       map[string]*pkg.Error
+}
+
+// CountOrphanRows returns the configured orphan rows of the child table, up to the limit.
+func (r fakeRepo) CountOrphanRows(childTable string, childColumns []string, parentTable string, parentColumns []string, limit int, timeout time.Duration) (int, *pkg.Error) {
+    if err, ok := r.errs[childTable]; ok {
+        return 0, err
+    }
+
+    if r.orphanRows[childTable] > limit {
+        return limit, nil
+    }
+
+    return r.orphanRows[childTable], nil
+}
+
+func TestRelationships(t *testing.T) {
+    testCases := map[string]struct {
+        tableList []domain.TableTmplValue
+        expected  []Relationship
+    }{
+        "constraints without references are skipped": {
+            tableList: []domain.TableTmplValue{
+                {
+                    TableName: "users",
+                    ConstraintsList: []domain.ConstraintTmplValue{
+                        {Name: "users_pkey", Type: "PRIMARY KEY", Column: "id"},
+                    },
+                },
+            },
+            expected: nil,
+        },
+        "composite foreign key is grouped to one relationship": {
+            tableList: []domain.TableTmplValue{
+                {
+                    TableName: "order_lines",
+                    ConstraintsList: []domain.ConstraintTmplValue{
+                        {Name: "order_lines_order_fkey", Type: "FOREIGN KEY", Column: "tenant_id", ReferencesTable: "orders", ReferencesColumn: "tenant_id"},
+                        {Name: "order_lines_product_fkey", Type: "FOREIGN KEY", Column: "product_id", ReferencesTable: "products", ReferencesColumn: "id"},
+                        {Name: "order_lines_order_fkey", Type: "FOREIGN KEY", Column: "order_id", ReferencesTable: "orders", ReferencesColumn: "id"},
+                    },
+                },
+            },
+            expected: []Relationship{
+                {Name: "order_lines_order_fkey", ChildTable: "order_lines", ChildColumns: []string{"tenant_id", "order_id"}, ParentTable: "orders", ParentColumns: []string{"tenant_id", "id"}},
+                {Name: "order_lines_product_fkey", ChildTable: "order_lines", ChildColumns: []string{"product_id"}, ParentTable: "products", ParentColumns: []string{"id"}},
+            },
+        },
+        "same constraint name of different tables is not grouped": {
+            tableList: []domain.TableTmplValue{
+                {
+                    TableName: "orders",
+                    ConstraintsList: []domain.ConstraintTmplValue{
+                        {Name: "fk_user", Type: "FOREIGN KEY", Column: "user_id", ReferencesTable: "users", ReferencesColumn: "id"},
+                    },
+                },
+                {
+                    TableName: "payments",
+                    ConstraintsList: []domain.ConstraintTmplValue{
+                        {Name: "fk_user", Type: "FOREIGN KEY", Column: "user_id", ReferencesTable: "users", ReferencesColumn: "id", Inferred: true},
+                    },
+                },
+            },
+            expected: []Relationship{
+                {Name: "fk_user", ChildTable: "orders", ChildColumns: []string{"user_id"}, ParentTable: "users", ParentColumns: []string{"id"}},
+                {Name: "fk_user", ChildTable: "payments", ChildColumns: []string{"user_id"}, ParentTable: "users", ParentColumns: []string{"id"}, Inferred: true},
+            },
+        },
+    }
+
+    for name, tc := range testCases {
+        t.Run(name, func(t *testing.T) {
+            actual := Relationships(domain.TemplateValues{TableList: tc.tableList})
+            if !reflect.DeepEqual(actual, tc.expected) {
+                t.Errorf("expected relationships %+v, got %+v", tc.expected, actual)
+            }
+        })
+    }
+}
+
+func TestCheckAndRender(t *testing.T) {
+    templateValues := domain.TemplateValues{
+        TableList: []domain.TableTmplValue{
+            {TableName: "clean", ConstraintsList: []domain.ConstraintTmplValue{{Name: "clean_fkey", Column: "user_id", ReferencesTable: "users", ReferencesColumn: "id"}}},
+            {TableName: "orphaned", ConstraintsList: []domain.ConstraintTmplValue{{Name: "orphaned_fkey", Column: "user_id", ReferencesTable: "users", ReferencesColumn: "id"}}},
+            {TableName: "limited", ConstraintsList: []domain.ConstraintTmplValue{{Name: "limited_fkey", Column: "user_id", ReferencesTable: "users", ReferencesColumn: "id", Inferred: true}}},
+            {TableName: "failed", ConstraintsList: []domain.ConstraintTmplValue{{Name: "failed_fkey", Column: "user_id", ReferencesTable: "users", ReferencesColumn: "id"}}},
+        },
+    }
+    repo := fakeRepo{
+        orphanRows: map[string]int{"orphaned": 3, "limited": 50},
+        errs:       map[string]*pkg.Error{"failed": {Err: fmt.Errorf("canceling statement due to statement timeout")}},
+    }
+
+    results := New(repo, 10, time.Second).Check(templateValues)
+    if !HasIssues(results) {
+        t.Error("expected the results to have issues")
+    }
+    if HasIssues(results[:1]) {
+        t.Error("expected the clean relationship to have no issues")
+    }
+
+    expectedLines := []string{
+        "OK       clean(user_id) -> users(id): no orphan rows",
+        "ORPHANS  orphaned(user_id) -> users(id): 3 orphan row(s)",
+        "ORPHANS  limited(user_id) -> users(id) [inferred]: at least 10 orphan row(s)",
+        "FAILED   failed(user_id) -> users(id): canceling statement due to statement timeout",
+        "4 relationship(s) checked: 2 with orphan rows, 1 failed",
+    }
+    report := Render(results)
+    for _, line := range expectedLines {
+        if !strings.Contains(report, line+"\n") {
+            t.Errorf("expected the report to contain %q, got:\n%v", line, report)
+        }
+    }
+}
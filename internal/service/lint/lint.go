@@ -0,0 +1,106 @@
+package lint
+
+import (
+    "fmt"
+    "sort"
+
+    "github.com/eujoy/data-dict/internal/model/domain"
+    "github.com/eujoy/data-dict/pkg"
+)
+
+const (
+    // SeverityError describes the severity of findings that make the lint fail.
+    SeverityError = "error"
+    // SeverityWarning describes the severity of findings that should be looked at.
+    SeverityWarning = "warning"
+    // SeverityInfo describes the severity of findings that are informative only.
+    SeverityInfo = "info"
+)
+
+// Finding describes a single issue reported by a lint rule.
+type Finding struct {
+    RuleID   string `json:"rule"`
+    Severity string `json:"severity"`
+    Table    string `json:"table"`
+    Column   string `json:"column,omitempty"`
+    Object   string `json:"object,omitempty"`
+    Message  string `json:"message"`
+}
+
+// RuleSetting describes the configurable behaviour of a lint rule.
+type RuleSetting struct {
+    Enabled  bool
+    Severity string
+}
+
+// Service describes the lint service which runs the enabled rules against the template values.
+type Service struct {
+    schema     string
+    settings   map[string]RuleSetting
+    migrations *migrationIndex
+}
+
+// New creates and returns a new lint service. Rules that are not part of the provided settings
+// are enabled with their default severity.
+func New(schema string, settings map[string]RuleSetting) (*Service, *pkg.Error) {
+    ruleSettings := make(map[string]RuleSetting)
+    for _, r := range rules {
+        ruleSettings[r.id] = RuleSetting{Enabled: true, Severity: r.severity}
+    }
+
+    for ruleID, setting := range settings {
+        if _, ok := ruleSettings[ruleID]; !ok {
+            return nil, &pkg.Error{Err: fmt.Errorf("unknown lint rule provided: %v", ruleID)}
+        }
+
+        switch setting.Severity {
+        case "":
+            setting.Severity = ruleSettings[ruleID].Severity
+        case SeverityError, SeverityWarning, SeverityInfo:
+        default:
+            return nil, &pkg.Error{Err: fmt.Errorf("invalid severity '%v' provided for lint rule: %v", setting.Severity, ruleID)}
+        }
+
+        ruleSettings[ruleID] = setting
+    }
+
+    return &Service{schema: schema, settings: ruleSettings}, nil
+}
+
+// Run executes all the enabled rules and returns the findings sorted by table and rule.
+func (s *Service) Run(templateValues domain.TemplateValues) []Finding {
+    var findings []Finding
+    for _, r := range rules {
+        setting := s.settings[r.id]
+        if !setting.Enabled {
+            continue
+        }
+
+        for _, f := range r.check(templateValues) {
+            f.RuleID = r.id
+            f.Severity = setting.Severity
+            findings = append(findings, f)
+        }
+    }
+
+    sort.SliceStable(findings, func(i int, j int) bool {
+        if findings[i].Table != findings[j].Table {
+            return findings[i].Table < findings[j].Table
+        }
+
+        return findings[i].RuleID < findings[j].RuleID
+    })
+
+    return findings
+}
+
+// HasErrors checks whether any of the findings has an error severity.
+func HasErrors(findings []Finding) bool {
+    for _, f := range findings {
+        if f.Severity == SeverityError {
+            return true
+        }
+    }
+
+    return false
+}
@@ -0,0 +1,232 @@
+package lint
+
+import (
+    "bufio"
+    "fmt"
+    "os"
+    "path/filepath"
+    "regexp"
+    "sort"
+    "strings"
+    "unicode"
+
+    "github.com/eujoy/data-dict/pkg"
+)
+
+var (
+    createTableRegex = regexp.MustCompile(`(?i)^\s*create\s+(?:(?:global\s+|local\s+)?(?:temporary|temp|unlogged)\s+)?table\s+(?:if\s+not\s+exists\s+)?(?:"?(\w+)"?\.)?"?(\w+)"?`)
+    alterTableRegex  = regexp.MustCompile(`(?i)^\s*alter\s+table\s+(?:if\s+exists\s+)?(?:only\s+)?(?:"?(\w+)"?\.)?"?(\w+)"?\s+add\s+(?:column\s+)?(?:if\s+not\s+exists\s+)?"?(\w+)"?`)
+    createIndexRegex = regexp.MustCompile(`(?i)^\s*create\s+(?:unique\s+)?index\s+(?:concurrently\s+)?(?:if\s+not\s+exists\s+)?"?(\w+)"?\s+on\s+(?:only\s+)?(?:"?(\w+)"?\.)?"?(\w+)"?`)
+    columnLineRegex  = regexp.MustCompile(`^\s*"?(\w+)"?\s+\w`)
+    constraintRegex  = regexp.MustCompile(`(?i)\b(?:(drop|rename)\s+)?constraint\s+(?:if\s+exists\s+)?"?(\w+)"?`)
+
+    tableElementKeywords = map[string]bool{
+        "constraint": true,
+        "primary":    true,
+        "foreign":    true,
+        "unique":     true,
+        "check":      true,
+        "exclude":    true,
+        "like":       true,
+    }
+)
+
+// sourceLocation describes the position of a statement inside a migration file.
+type sourceLocation struct {
+    file string
+    line int
+}
+
+// tableScan describes the progress of scanning the definition of a table, which may span multiple lines.
+type tableScan struct {
+    name      string // Empty for the tables of other schemas, whose definition is scanned but not recorded.
+    depth     int
+    inElement bool
+}
+
+// migrationIndex describes the locations of the migration statements that created each database object.
+type migrationIndex struct {
+    tables  map[string]sourceLocation
+    columns map[string]sourceLocation
+    objects map[string]sourceLocation
+}
+
+// LoadMigrations scans the sql files of the migrations directory, in lexical order, to locate the statements
+// which created the tables, columns, indexes and constraints of the schema.
+func (s *Service) LoadMigrations(dir string) *pkg.Error {
+    var files []string
+    walkErr := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+        if err != nil {
+            return err
+        }
+
+        if !info.IsDir() && strings.EqualFold(filepath.Ext(path), ".sql") {
+            files = append(files, path)
+        }
+
+        return nil
+    })
+    if walkErr != nil {
+        return &pkg.Error{Err: fmt.Errorf("failed to read migrations directory '%v' with error: %v", dir, walkErr)}
+    }
+
+    sort.Strings(files)
+
+    migrations := &migrationIndex{
+        tables:  make(map[string]sourceLocation),
+        columns: make(map[string]sourceLocation),
+        objects: make(map[string]sourceLocation),
+    }
+    for _, file := range files {
+        err := migrations.scanFile(file, s.schema)
+        if err != nil {
+            return err
+        }
+    }
+
+    s.migrations = migrations
+    return nil
+}
+
+// scanFile records the first location of every object created in the provided file.
+func (m *migrationIndex) scanFile(file string, schema string) *pkg.Error {
+    f, openErr := os.Open(file)
+    if openErr != nil {
+        return &pkg.Error{Err: openErr}
+    }
+    defer f.Close()
+
+    uri := fileURI(file)
+    var scan *tableScan
+    lineNumber := 0
+
+    scanner := bufio.NewScanner(f)
+    for scanner.Scan() {
+        lineNumber++
+        line := stripLineComment(scanner.Text())
+        loc := sourceLocation{file: uri, line: lineNumber}
+        body := line
+
+        if match := createTableRegex.FindStringSubmatch(line); match != nil {
+            scan = &tableScan{}
+            if isSchema(match[1], schema) {
+                scan.name = strings.ToLower(match[2])
+                m.record(m.tables, scan.name, loc)
+            }
+            body = line[len(match[0]):]
+        } else if match := alterTableRegex.FindStringSubmatch(line); match != nil {
+            scan = nil
+            if isSchema(match[1], schema) && !tableElementKeywords[strings.ToLower(match[3])] {
+                m.record(m.columns, strings.ToLower(match[2]+"."+match[3]), loc)
+            }
+        } else if match := createIndexRegex.FindStringSubmatch(line); match != nil {
+            scan = nil
+            if isSchema(match[2], schema) {
+                m.record(m.objects, strings.ToLower(match[1]), loc)
+            }
+            continue
+        }
+
+        for _, match := range constraintRegex.FindAllStringSubmatch(line, -1) {
+            if match[1] == "" {
+                m.record(m.objects, strings.ToLower(match[2]), loc)
+            }
+        }
+
+        if scan != nil && m.scanTableBody(scan, body, loc) {
+            scan = nil
+        }
+    }
+
+    if scanErr := scanner.Err(); scanErr != nil {
+        return &pkg.Error{Err: scanErr}
+    }
+
+    return nil
+}
+
+// scanTableBody records the columns defined in the provided part of the definition of a table, i.e. the first word of
+// each top level element apart from the table constraints. It returns whether the definition of the table is complete.
+func (m *migrationIndex) scanTableBody(scan *tableScan, text string, loc sourceLocation) bool {
+    for i, ch := range text {
+        switch {
+        case scan.depth == 0 && ch == ';':
+            return true
+        case scan.depth == 0:
+            if ch == '(' {
+                scan.depth = 1
+                scan.inElement = false
+            }
+        case ch == '(':
+            scan.depth++
+        case ch == ')':
+            scan.depth--
+            if scan.depth == 0 {
+                return true
+            }
+        case ch == ',' && scan.depth == 1:
+            scan.inElement = false
+        case scan.depth == 1 && !scan.inElement && !unicode.IsSpace(ch):
+            scan.inElement = true
+            match := columnLineRegex.FindStringSubmatch(text[i:])
+            if scan.name != "" && match != nil && !tableElementKeywords[strings.ToLower(match[1])] {
+                m.record(m.columns, strings.ToLower(scan.name+"."+match[1]), loc)
+            }
+        }
+    }
+
+    return false
+}
+
+// record keeps the location of an object unless it has already been created by an earlier migration.
+func (m *migrationIndex) record(locations map[string]sourceLocation, key string, loc sourceLocation) {
+    if _, ok := locations[key]; !ok {
+        locations[key] = loc
+    }
+}
+
+// locate returns the location of the migration that created the object the finding refers to.
+func (m *migrationIndex) locate(f Finding) (sourceLocation, bool) {
+    if f.Object != "" {
+        if loc, ok := m.objects[strings.ToLower(f.Object)]; ok {
+            return loc, true
+        }
+    }
+
+    if f.Column != "" {
+        column := strings.Split(f.Column, ",")[0]
+        if loc, ok := m.columns[strings.ToLower(f.Table+"."+column)]; ok {
+            return loc, true
+        }
+    }
+
+    loc, ok := m.tables[strings.ToLower(f.Table)]
+    return loc, ok
+}
+
+// stripLineComment removes the trailing '--' comment of a line.
+func stripLineComment(line string) string {
+    if pos := strings.Index(line, "--"); pos >= 0 {
+        return line[:pos]
+    }
+
+    return line
+}
+
+// isSchema checks whether the (optional) schema qualifier of a statement refers to the provided schema.
+func isSchema(qualifier string, schema string) bool {
+    return qualifier == "" || strings.EqualFold(qualifier, schema)
+}
+
+// fileURI returns the path of the file relative to the working directory using forward slashes.
+func fileURI(file string) string {
+    if wd, err := os.Getwd(); err == nil {
+        if abs, err := filepath.Abs(file); err == nil {
+            if rel, err := filepath.Rel(wd, abs); err == nil && !strings.HasPrefix(rel, "..") {
+                return filepath.ToSlash(rel)
+            }
+        }
+    }
+
+    return filepath.ToSlash(file)
+}
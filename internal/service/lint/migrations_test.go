@@ -0,0 +1,135 @@
+package lint
+
+import (
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+// loadTestMigrations writes the provided migration files to a temporary directory and loads them.
+func loadTestMigrations(t *testing.T, files map[string]string) *migrationIndex {
+    dir, tmpErr := ioutil.TempDir("", "migrations")
+    if tmpErr != nil {
+        t.Fatalf("unexpected error: %v", tmpErr)
+    }
+    t.Cleanup(func() { os.RemoveAll(dir) })
+
+    for name, content := range files {
+        if writeErr := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); writeErr != nil {
+            t.Fatalf("unexpected error: %v", writeErr)
+        }
+    }
+
+    s, err := New("public", nil)
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err.Err)
+    }
+
+    if err := s.LoadMigrations(dir); err != nil {
+        t.Fatalf("unexpected error: %v", err.Err)
+    }
+
+    return s.migrations
+}
+
+func TestLoadMigrations(t *testing.T) {
+    migrations := loadTestMigrations(t, map[string]string{
+        "001_init.sql": strings.Join([]string{
+            `CREATE TABLE users (`,                                       // 1
+            `    id bigint PRIMARY KEY, -- the id of the user`,           // 2
+            `    -- the email, unique per tenant`,                        // 3
+            `    email text NOT NULL,`,                                   // 4
+            `    balance numeric(12, 2), tenant_id bigint,`,              // 5
+            `    CONSTRAINT users_email_key UNIQUE (tenant_id, email)`,   // 6
+            `);`,                                                         // 7
+            `CREATE TABLE tags (id int, "Name" text, PRIMARY KEY (id));`, // 8
+            `INSERT INTO users (id, email) VALUES (1, 'a@example.com');`, // 9
+            `CREATE TABLE other.users (ignored int);`,                    // 10
+            `CREATE INDEX users_tenant_idx ON public.users (tenant_id);`, // 11
+        }, "\n"),
+        "002_alter.sql": strings.Join([]string{
+            `ALTER TABLE users ADD COLUMN nickname text;`,                                          // 1
+            `ALTER TABLE users DROP CONSTRAINT users_email_key;`,                                   // 2
+            `ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);`,                     // 3
+            `ALTER TABLE users RENAME CONSTRAINT users_nickname_check TO users_nickname_length;`,   // 4
+            `ALTER TABLE users ADD CONSTRAINT users_nickname_check CHECK (length(nickname) < 32);`, // 5
+            `CREATE TABLE events AS SELECT id, email FROM users;`,                                  // 6
+            `INSERT INTO tags (id, name) VALUES (1, 'new');`,                                       // 7
+            `ALTER TABLE users DROP CONSTRAINT IF EXISTS users_legacy_key;`,                        // 8
+        }, "\n"),
+    })
+
+    testCases := map[string]struct {
+        locations map[string]sourceLocation
+        key       string
+        file      string
+        line      int
+        found     bool
+    }{
+        "multi line table":                     {locations: migrations.tables, key: "users", file: "001_init.sql", line: 1, found: true},
+        "column before a trailing comment":     {locations: migrations.columns, key: "users.id", file: "001_init.sql", line: 2, found: true},
+        "column after a comment line":          {locations: migrations.columns, key: "users.email", file: "001_init.sql", line: 4, found: true},
+        "first of multiple columns of a line":  {locations: migrations.columns, key: "users.balance", file: "001_init.sql", line: 5, found: true},
+        "second of multiple columns of a line": {locations: migrations.columns, key: "users.tenant_id", file: "001_init.sql", line: 5, found: true},
+        "table constraint is not a column":     {locations: migrations.columns, key: "users.constraint", found: false},
+        "single line table":                    {locations: migrations.tables, key: "tags", file: "001_init.sql", line: 8, found: true},
+        "column of a single line table":        {locations: migrations.columns, key: "tags.name", file: "001_init.sql", line: 8, found: true},
+        "primary key of a single line table":   {locations: migrations.columns, key: "tags.primary", found: false},
+        "insert after a table is not a column": {locations: migrations.columns, key: "users.insert", found: false},
+        "table of another schema is skipped":   {locations: migrations.columns, key: "users.ignored", found: false},
+        "index":                                {locations: migrations.objects, key: "users_tenant_idx", file: "001_init.sql", line: 11, found: true},
+        "added column":                         {locations: migrations.columns, key: "users.nickname", file: "002_alter.sql", line: 1, found: true},
+        "first creation of a constraint":       {locations: migrations.objects, key: "users_email_key", file: "001_init.sql", line: 6, found: true},
+        "added constraint after renaming":      {locations: migrations.objects, key: "users_nickname_check", file: "002_alter.sql", line: 5, found: true},
+        "renamed constraint is not a creation": {locations: migrations.objects, key: "users_nickname_length", found: false},
+        "dropped constraint is not a creation": {locations: migrations.objects, key: "users_legacy_key", found: false},
+        "table created from a query":           {locations: migrations.tables, key: "events", file: "002_alter.sql", line: 6, found: true},
+        "insert after a table from a query":    {locations: migrations.columns, key: "events.id", found: false},
+    }
+
+    for name, tc := range testCases {
+        t.Run(name, func(t *testing.T) {
+            loc, ok := tc.locations[tc.key]
+            if ok != tc.found {
+                t.Fatalf("expected '%v' to be found %v, got %v (%+v)", tc.key, tc.found, ok, loc)
+            }
+            if !tc.found {
+                return
+            }
+
+            if !strings.HasSuffix(loc.file, "/"+tc.file) || loc.line != tc.line {
+                t.Errorf("expected '%v' at %v:%d, got %v:%d", tc.key, tc.file, tc.line, loc.file, loc.line)
+            }
+        })
+    }
+}
+
+func TestLocate(t *testing.T) {
+    migrations := &migrationIndex{
+        tables:  map[string]sourceLocation{"users": {file: "001.sql", line: 1}},
+        columns: map[string]sourceLocation{"users.email": {file: "001.sql", line: 3}},
+        objects: map[string]sourceLocation{"users_email_idx": {file: "002.sql", line: 7}},
+    }
+
+    testCases := map[string]struct {
+        finding  Finding
+        expected sourceLocation
+        found    bool
+    }{
+        "object":                            {finding: Finding{Table: "users", Column: "email", Object: "Users_Email_Idx"}, expected: sourceLocation{file: "002.sql", line: 7}, found: true},
+        "first column of an unknown object": {finding: Finding{Table: "users", Column: "email,id", Object: "users_fkey"}, expected: sourceLocation{file: "001.sql", line: 3}, found: true},
+        "table of an unknown column":        {finding: Finding{Table: "users", Column: "id"}, expected: sourceLocation{file: "001.sql", line: 1}, found: true},
+        "unknown table":                     {finding: Finding{Table: "orders"}, found: false},
+    }
+
+    for name, tc := range testCases {
+        t.Run(name, func(t *testing.T) {
+            loc, ok := migrations.locate(tc.finding)
+            if ok != tc.found || loc != tc.expected {
+                t.Errorf("expected location %+v (%v), got %+v (%v)", tc.expected, tc.found, loc, ok)
+            }
+        })
+    }
+}
@@ -0,0 +1,70 @@
+package lint
+
+import (
+    "encoding/json"
+    "fmt"
+    "strings"
+
+    "github.com/eujoy/data-dict/pkg"
+)
+
+const (
+    textFormat  = "text"
+    jsonFormat  = "json"
+    sarifFormat = "sarif"
+)
+
+// Render formats the findings to the requested output format.
+func (s *Service) Render(format string, findings []Finding) (string, *pkg.Error) {
+    switch format {
+    case textFormat:
+        return renderText(findings), nil
+    case jsonFormat:
+        return renderJSON(findings)
+    case sarifFormat:
+        return s.renderSARIF(findings)
+    default:
+        return "", &pkg.Error{Err: fmt.Errorf("invalid lint format provided: %v", format)}
+    }
+}
+
+// renderText formats the findings as one line per finding followed by a summary.
+func renderText(findings []Finding) string {
+    var sb strings.Builder
+    counts := make(map[string]int)
+    for _, f := range findings {
+        location := f.Table
+        if f.Column != "" {
+            location = fmt.Sprintf("%v.%v", f.Table, f.Column)
+        }
+
+        sb.WriteString(fmt.Sprintf("%-7v %-30v %v [%v]\n", strings.ToUpper(f.Severity), location, f.Message, f.RuleID))
+        counts[f.Severity]++
+    }
+
+    sb.WriteString(fmt.Sprintf(
+        "\n%d finding(s): %d error(s), %d warning(s), %d info\n",
+        len(findings),
+        counts[SeverityError],
+        counts[SeverityWarning],
+        counts[SeverityInfo],
+    ))
+
+    return sb.String()
+}
+
+// renderJSON formats the findings as a json document.
+func renderJSON(findings []Finding) (string, *pkg.Error) {
+    if findings == nil {
+        findings = []Finding{}
+    }
+
+    data, marshalErr := json.MarshalIndent(struct {
+        Findings []Finding `json:"findings"`
+    }{Findings: findings}, "", "  ")
+    if marshalErr != nil {
+        return "", &pkg.Error{Err: marshalErr}
+    }
+
+    return string(data) + "\n", nil
+}
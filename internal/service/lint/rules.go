@@ -0,0 +1,381 @@
+package lint
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+
+    "github.com/eujoy/data-dict/internal/model/domain"
+)
+
+const (
+    ruleTableWithoutPrimaryKey   = "table-without-primary-key"
+    ruleForeignKeyWithoutIndex   = "foreign-key-without-index"
+    ruleNullableForeignKey       = "nullable-foreign-key"
+    ruleNamingSnakeCase          = "naming-snake-case"
+    ruleNamingForeignKeySuffix   = "naming-foreign-key-suffix"
+    ruleMissingComment           = "missing-comment"
+    ruleTimestampWithoutTimeZone = "timestamp-without-time-zone"
+    ruleRedundantIndex           = "redundant-index"
+)
+
+var snakeCaseRegex = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// rule describes a lint rule along with its default severity.
+type rule struct {
+    id          string
+    description string
+    severity    string
+    check       func(templateValues domain.TemplateValues) []Finding
+}
+
+var rules = []rule{
+    {
+        id:          ruleTableWithoutPrimaryKey,
+        description: "Tables should define a primary key.",
+        severity:    SeverityError,
+        check:       checkTableWithoutPrimaryKey,
+    },
+    {
+        id:          ruleForeignKeyWithoutIndex,
+        description: "Foreign keys should be supported by an index on the referencing columns.",
+        severity:    SeverityWarning,
+        check:       checkForeignKeyWithoutIndex,
+    },
+    {
+        id:          ruleNullableForeignKey,
+        description: "Foreign key columns should not be nullable.",
+        severity:    SeverityWarning,
+        check:       checkNullableForeignKey,
+    },
+    {
+        id:          ruleNamingSnakeCase,
+        description: "Table and column names should be in snake_case.",
+        severity:    SeverityWarning,
+        check:       checkNamingSnakeCase,
+    },
+    {
+        id:          ruleNamingForeignKeySuffix,
+        description: "Foreign key columns should have the '_id' suffix.",
+        severity:    SeverityInfo,
+        check:       checkNamingForeignKeySuffix,
+    },
+    {
+        id:          ruleMissingComment,
+        description: "Tables and columns should be documented with a comment.",
+        severity:    SeverityInfo,
+        check:       checkMissingComment,
+    },
+    {
+        id:          ruleTimestampWithoutTimeZone,
+        description: "Columns should use 'timestamp with time zone' instead of 'timestamp'.",
+        severity:    SeverityWarning,
+        check:       checkTimestampWithoutTimeZone,
+    },
+    {
+        id:          ruleRedundantIndex,
+        description: "Indexes should not duplicate or be a prefix of another index.",
+        severity:    SeverityWarning,
+        check:       checkRedundantIndex,
+    },
+}
+
+// checkTableWithoutPrimaryKey reports the tables that do not have a primary key.
+func checkTableWithoutPrimaryKey(templateValues domain.TemplateValues) []Finding {
+    var findings []Finding
+    for _, tb := range templateValues.TableList {
+        hasPK := false
+        for _, col := range tb.ColumnList {
+            if col.PK {
+                hasPK = true
+                break
+            }
+        }
+
+        if !hasPK {
+            findings = append(findings, Finding{
+                Table:   tb.TableName,
+                Message: fmt.Sprintf("table '%v' does not have a primary key", tb.TableName),
+            })
+        }
+    }
+
+    return findings
+}
+
+// checkForeignKeyWithoutIndex reports the foreign keys whose columns are not the leading columns of any index.
+func checkForeignKeyWithoutIndex(templateValues domain.TemplateValues) []Finding {
+    var findings []Finding
+    for _, tb := range templateValues.TableList {
+        for _, fk := range foreignKeysOfTable(tb) {
+            if hasSupportingIndex(tb.IndexList, fk.columns) {
+                continue
+            }
+
+            findings = append(findings, Finding{
+                Table:   tb.TableName,
+                Column:  strings.Join(fk.columns, ","),
+                Object:  fk.name,
+                Message: fmt.Sprintf("foreign key '%v' on '%v(%v)' is not supported by an index", fk.name, tb.TableName, strings.Join(fk.columns, ", ")),
+            })
+        }
+    }
+
+    return findings
+}
+
+// checkNullableForeignKey reports the foreign key columns that are nullable.
+func checkNullableForeignKey(templateValues domain.TemplateValues) []Finding {
+    var findings []Finding
+    for _, tb := range templateValues.TableList {
+        for _, col := range tb.ColumnList {
+            if col.FK && !col.NotNull {
+                findings = append(findings, Finding{
+                    Table:   tb.TableName,
+                    Column:  col.Name,
+                    Message: fmt.Sprintf("foreign key column '%v.%v' is nullable", tb.TableName, col.Name),
+                })
+            }
+        }
+    }
+
+    return findings
+}
+
+// checkNamingSnakeCase reports the tables and columns which are not named in snake_case.
+func checkNamingSnakeCase(templateValues domain.TemplateValues) []Finding {
+    var findings []Finding
+    for _, tb := range templateValues.TableList {
+        if !snakeCaseRegex.MatchString(tb.TableName) {
+            findings = append(findings, Finding{
+                Table:   tb.TableName,
+                Message: fmt.Sprintf("table name '%v' is not in snake_case", tb.TableName),
+            })
+        }
+
+        for _, col := range tb.ColumnList {
+            if !snakeCaseRegex.MatchString(col.Name) {
+                findings = append(findings, Finding{
+                    Table:   tb.TableName,
+                    Column:  col.Name,
+                    Message: fmt.Sprintf("column name '%v.%v' is not in snake_case", tb.TableName, col.Name),
+                })
+            }
+        }
+    }
+
+    return findings
+}
+
+// checkNamingForeignKeySuffix reports the foreign key columns which do not end with '_id'.
+func checkNamingForeignKeySuffix(templateValues domain.TemplateValues) []Finding {
+    var findings []Finding
+    for _, tb := range templateValues.TableList {
+        for _, col := range tb.ColumnList {
+            if col.FK && !strings.HasSuffix(col.Name, "_id") {
+                findings = append(findings, Finding{
+                    Table:   tb.TableName,
+                    Column:  col.Name,
+                    Message: fmt.Sprintf("foreign key column '%v.%v' does not have the '_id' suffix", tb.TableName, col.Name),
+                })
+            }
+        }
+    }
+
+    return findings
+}
+
+// checkMissingComment reports the tables and columns that do not have a comment.
+func checkMissingComment(templateValues domain.TemplateValues) []Finding {
+    var findings []Finding
+    for _, tb := range templateValues.TableList {
+        if strings.TrimSpace(tb.Comment) == "" {
+            findings = append(findings, Finding{
+                Table:   tb.TableName,
+                Message: fmt.Sprintf("table '%v' does not have a comment", tb.TableName),
+            })
+        }
+
+        for _, col := range tb.ColumnList {
+            if strings.TrimSpace(col.Comment) == "" {
+                findings = append(findings, Finding{
+                    Table:   tb.TableName,
+                    Column:  col.Name,
+                    Message: fmt.Sprintf("column '%v.%v' does not have a comment", tb.TableName, col.Name),
+                })
+            }
+        }
+    }
+
+    return findings
+}
+
+// checkTimestampWithoutTimeZone reports the columns defined as 'timestamp without time zone'.
+func checkTimestampWithoutTimeZone(templateValues domain.TemplateValues) []Finding {
+    var findings []Finding
+    for _, tb := range templateValues.TableList {
+        for _, col := range tb.ColumnList {
+            if col.RawDataType == "timestamp" {
+                findings = append(findings, Finding{
+                    Table:   tb.TableName,
+                    Column:  col.Name,
+                    Message: fmt.Sprintf("column '%v.%v' is a timestamp without time zone", tb.TableName, col.Name),
+                })
+            }
+        }
+    }
+
+    return findings
+}
+
+// checkRedundantIndex reports the indexes that are identical to or a leading prefix of another index of the same table
+// and method. Partial and expression indexes are never compared, while the included (non-key) columns of an index must
+// be covered by the other index for it to be reported.
+func checkRedundantIndex(templateValues domain.TemplateValues) []Finding {
+    var findings []Finding
+    for _, tb := range templateValues.TableList {
+        for _, idx := range tb.IndexList {
+            if idx.Primary || !isPlainIndex(idx) || len(idx.Columns) == 0 {
+                continue
+            }
+
+            for _, other := range tb.IndexList {
+                if other.Name == idx.Name || !isPlainIndex(other) || other.Method != idx.Method || !coversIncludedColumns(other, idx) {
+                    continue
+                }
+
+                if isSameColumnList(idx.Columns, other.Columns) {
+                    if other.Primary || (other.Unique && !idx.Unique) || (other.Unique == idx.Unique && isPreferredIndex(other, idx)) {
+                        findings = append(findings, Finding{
+                            Table:   tb.TableName,
+                            Column:  strings.Join(idx.Columns, ","),
+                            Object:  idx.Name,
+                            Message: fmt.Sprintf("index '%v' is a duplicate of index '%v'", idx.Name, other.Name),
+                        })
+                        break
+                    }
+
+                    continue
+                }
+
+                if !idx.Unique && len(idx.Columns) < len(other.Columns) && isSameColumnList(idx.Columns, other.Columns[:len(idx.Columns)]) {
+                    findings = append(findings, Finding{
+                        Table:   tb.TableName,
+                        Column:  strings.Join(idx.Columns, ","),
+                        Object:  idx.Name,
+                        Message: fmt.Sprintf("index '%v' is redundant as it is a prefix of index '%v'", idx.Name, other.Name),
+                    })
+                    break
+                }
+            }
+        }
+    }
+
+    return findings
+}
+
+// foreignKey describes a (possibly multi column) foreign key of a table.
+type foreignKey struct {
+    name    string
+    columns []string
+}
+
+// foreignKeysOfTable groups the foreign key constraints of a table by their name.
+func foreignKeysOfTable(tb domain.TableTmplValue) []foreignKey {
+    var foreignKeys []foreignKey
+    fkPosition := make(map[string]int)
+    for _, constr := range tb.ConstraintsList {
+        if constr.Type != "FOREIGN KEY" || constr.Inferred {
+            continue
+        }
+
+        pos, ok := fkPosition[constr.Name]
+        if !ok {
+            pos = len(foreignKeys)
+            fkPosition[constr.Name] = pos
+            foreignKeys = append(foreignKeys, foreignKey{name: constr.Name})
+        }
+
+        foreignKeys[pos].columns = append(foreignKeys[pos].columns, constr.Column)
+    }
+
+    return foreignKeys
+}
+
+// hasSupportingIndex checks whether the provided columns are the leading columns of any index.
+func hasSupportingIndex(indexList []domain.IndexTmplValue, columns []string) bool {
+    for _, idx := range indexList {
+        if len(idx.Columns) < len(columns) || !isPlainIndex(idx) {
+            continue
+        }
+
+        leading := make(map[string]bool)
+        for _, col := range idx.Columns[:len(columns)] {
+            leading[col] = true
+        }
+
+        supported := true
+        for _, col := range columns {
+            if !leading[col] {
+                supported = false
+                break
+            }
+        }
+
+        if supported {
+            return true
+        }
+    }
+
+    return false
+}
+
+// isPlainIndex checks whether the index is defined on columns only, i.e. without a predicate or expressions.
+func isPlainIndex(idx domain.IndexTmplValue) bool {
+    return !idx.Partial && !idx.Expression
+}
+
+// coversIncludedColumns checks whether the included (non-key) columns of the index are either key or included columns
+// of the other index.
+func coversIncludedColumns(other domain.IndexTmplValue, idx domain.IndexTmplValue) bool {
+    otherColumns := make(map[string]bool)
+    for _, col := range other.Columns {
+        otherColumns[col] = true
+    }
+    for _, col := range other.IncludeColumns {
+        otherColumns[col] = true
+    }
+
+    for _, col := range idx.IncludeColumns {
+        if !otherColumns[col] {
+            return false
+        }
+    }
+
+    return true
+}
+
+// isPreferredIndex checks whether the other index should be kept over the index with the same key columns, i.e. when
+// it includes more columns or, when they include the same columns, by their name.
+func isPreferredIndex(other domain.IndexTmplValue, idx domain.IndexTmplValue) bool {
+    if len(other.IncludeColumns) != len(idx.IncludeColumns) {
+        return len(other.IncludeColumns) > len(idx.IncludeColumns)
+    }
+
+    return other.Name < idx.Name
+}
+
+// isSameColumnList checks whether the two column lists are identical.
+func isSameColumnList(left []string, right []string) bool {
+    if len(left) != len(right) {
+        return false
+    }
+
+    for i := range left {
+        if left[i] != right[i] {
+            return false
+        }
+    }
+
+    return true
+}
@@ -0,0 +1,248 @@
+package lint
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/eujoy/data-dict/internal/model/domain"
+)
+
+// findingObjects returns the objects (or columns, when no object is set) of the findings.
+func findingObjects(findings []Finding) []string {
+    var objects []string
+    for _, f := range findings {
+        if f.Object != "" {
+            objects = append(objects, f.Object)
+            continue
+        }
+
+        objects = append(objects, f.Table+"."+f.Column)
+    }
+
+    return objects
+}
+
+func TestCheckMissingComment(t *testing.T) {
+    templateValues := domain.TemplateValues{
+        TableList: []domain.TableTmplValue{
+            {
+                TableName: "users",
+                Comment:   "The registered users.",
+                ColumnList: []domain.ColumnTmplValue{
+                    {Name: "id", Comment: "The id of the user."},
+                    {Name: "email", Comment: "  "},
+                },
+            },
+            {
+                TableName: "sessions",
+                ColumnList: []domain.ColumnTmplValue{
+                    {Name: "id", Comment: "The id of the session."},
+                },
+            },
+        },
+    }
+
+    expected := []Finding{
+        {Table: "users", Column: "email", Message: "column 'users.email' does not have a comment"},
+        {Table: "sessions", Message: "table 'sessions' does not have a comment"},
+    }
+    if actual := checkMissingComment(templateValues); !reflect.DeepEqual(actual, expected) {
+        t.Errorf("expected findings %+v, got %+v", expected, actual)
+    }
+}
+
+func TestCheckRedundantIndex(t *testing.T) {
+    testCases := map[string]struct {
+        indexList []domain.IndexTmplValue
+        expected  []string
+    }{
+        "duplicate index is reported once": {
+            indexList: []domain.IndexTmplValue{
+                {Name: "users_email_a", Columns: []string{"email"}, Method: "btree"},
+                {Name: "users_email_b", Columns: []string{"email"}, Method: "btree"},
+            },
+            expected: []string{"users_email_b"},
+        },
+        "duplicate of the primary key is reported": {
+            indexList: []domain.IndexTmplValue{
+                {Name: "users_pkey", Columns: []string{"id"}, Method: "btree", Unique: true, Primary: true},
+                {Name: "users_id_idx", Columns: []string{"id"}, Method: "btree"},
+            },
+            expected: []string{"users_id_idx"},
+        },
+        "prefix index is reported": {
+            indexList: []domain.IndexTmplValue{
+                {Name: "users_a_idx", Columns: []string{"a"}, Method: "btree"},
+                {Name: "users_a_b_idx", Columns: []string{"a", "b"}, Method: "btree"},
+            },
+            expected: []string{"users_a_idx"},
+        },
+        "unique prefix index is not reported": {
+            indexList: []domain.IndexTmplValue{
+                {Name: "users_a_key", Columns: []string{"a"}, Method: "btree", Unique: true},
+                {Name: "users_a_b_idx", Columns: []string{"a", "b"}, Method: "btree"},
+            },
+            expected: nil,
+        },
+        "expression index is not compared": {
+            indexList: []domain.IndexTmplValue{
+                {Name: "users_lower_email_idx", Columns: nil, Method: "btree", Expression: true},
+                {Name: "users_tenant_lower_email_idx", Columns: []string{"tenant_id"}, Method: "btree", Expression: true},
+                {Name: "users_tenant_idx", Columns: []string{"tenant_id"}, Method: "btree"},
+            },
+            expected: nil,
+        },
+        "partial index is not compared": {
+            indexList: []domain.IndexTmplValue{
+                {Name: "users_email_active_idx", Columns: []string{"email"}, Method: "btree", Partial: true},
+                {Name: "users_email_idx", Columns: []string{"email"}, Method: "btree"},
+            },
+            expected: nil,
+        },
+        "index of another method is not compared": {
+            indexList: []domain.IndexTmplValue{
+                {Name: "users_email_hash_idx", Columns: []string{"email"}, Method: "hash"},
+                {Name: "users_email_idx", Columns: []string{"email"}, Method: "btree"},
+            },
+            expected: nil,
+        },
+        "included columns are not key columns": {
+            indexList: []domain.IndexTmplValue{
+                {Name: "users_a_incl_c_idx", Columns: []string{"a"}, IncludeColumns: []string{"c"}, Method: "btree"},
+                {Name: "users_a_b_idx", Columns: []string{"a", "b"}, Method: "btree"},
+            },
+            expected: nil,
+        },
+        "prefix index with covered included columns is reported": {
+            indexList: []domain.IndexTmplValue{
+                {Name: "users_a_incl_b_idx", Columns: []string{"a"}, IncludeColumns: []string{"b"}, Method: "btree"},
+                {Name: "users_a_b_idx", Columns: []string{"a", "b"}, Method: "btree"},
+            },
+            expected: []string{"users_a_incl_b_idx"},
+        },
+        "duplicate index including fewer columns is reported": {
+            indexList: []domain.IndexTmplValue{
+                {Name: "users_a_incl_b_idx", Columns: []string{"a"}, IncludeColumns: []string{"b"}, Method: "btree"},
+                {Name: "users_b_idx", Columns: []string{"a"}, Method: "btree"},
+            },
+            expected: []string{"users_b_idx"},
+        },
+    }
+
+    for name, tc := range testCases {
+        t.Run(name, func(t *testing.T) {
+            templateValues := domain.TemplateValues{
+                TableList: []domain.TableTmplValue{{TableName: "users", IndexList: tc.indexList}},
+            }
+
+            if actual := findingObjects(checkRedundantIndex(templateValues)); !reflect.DeepEqual(actual, tc.expected) {
+                t.Errorf("expected redundant indexes %v, got %v", tc.expected, actual)
+            }
+        })
+    }
+}
+
+func TestCheckForeignKeyWithoutIndex(t *testing.T) {
+    constraintsList := []domain.ConstraintTmplValue{
+        {Name: "order_lines_pkey", Type: "PRIMARY KEY", Column: "id"},
+        {Name: "order_lines_order_fkey", Type: "FOREIGN KEY", Column: "tenant_id", ReferencesTable: "orders", ReferencesColumn: "tenant_id"},
+        {Name: "order_lines_order_fkey", Type: "FOREIGN KEY", Column: "order_id", ReferencesTable: "orders", ReferencesColumn: "id"},
+        {Name: "order_lines_product_id_inferred", Type: "FOREIGN KEY", Column: "product_id", ReferencesTable: "products", ReferencesColumn: "id", Inferred: true},
+    }
+
+    testCases := map[string]struct {
+        indexList []domain.IndexTmplValue
+        expected  []string
+    }{
+        "composite foreign key without index is reported": {
+            indexList: nil,
+            expected:  []string{"order_lines_order_fkey"},
+        },
+        "composite foreign key with a covering index is supported": {
+            indexList: []domain.IndexTmplValue{
+                {Name: "order_lines_order_idx", Columns: []string{"tenant_id", "order_id"}, Method: "btree"},
+            },
+            expected: nil,
+        },
+        "composite foreign key with leading columns in another order is supported": {
+            indexList: []domain.IndexTmplValue{
+                {Name: "order_lines_order_tenant_idx", Columns: []string{"order_id", "tenant_id", "id"}, Method: "btree"},
+            },
+            expected: nil,
+        },
+        "composite foreign key with only some of the leading columns is reported": {
+            indexList: []domain.IndexTmplValue{
+                {Name: "order_lines_tenant_idx", Columns: []string{"tenant_id", "id"}, Method: "btree"},
+            },
+            expected: []string{"order_lines_order_fkey"},
+        },
+        "partial index does not support the foreign key": {
+            indexList: []domain.IndexTmplValue{
+                {Name: "order_lines_order_idx", Columns: []string{"tenant_id", "order_id"}, Method: "btree", Partial: true},
+            },
+            expected: []string{"order_lines_order_fkey"},
+        },
+    }
+
+    for name, tc := range testCases {
+        t.Run(name, func(t *testing.T) {
+            templateValues := domain.TemplateValues{
+                TableList: []domain.TableTmplValue{{TableName: "order_lines", ConstraintsList: constraintsList, IndexList: tc.indexList}},
+            }
+
+            if actual := findingObjects(checkForeignKeyWithoutIndex(templateValues)); !reflect.DeepEqual(actual, tc.expected) {
+                t.Errorf("expected foreign keys %v, got %v", tc.expected, actual)
+            }
+        })
+    }
+}
+
+func TestCheckNamingSnakeCase(t *testing.T) {
+    templateValues := domain.TemplateValues{
+        TableList: []domain.TableTmplValue{
+            {TableName: "order_lines", ColumnList: []domain.ColumnTmplValue{{Name: "id"}, {Name: "orderId"}, {Name: "line_2"}}},
+            {TableName: "OrderHistory", ColumnList: []domain.ColumnTmplValue{{Name: "_id"}}},
+        },
+    }
+
+    expected := []string{"order_lines.orderId", "OrderHistory.", "OrderHistory._id"}
+    if actual := findingObjects(checkNamingSnakeCase(templateValues)); !reflect.DeepEqual(actual, expected) {
+        t.Errorf("expected findings %v, got %v", expected, actual)
+    }
+}
+
+func TestRunRuleSettings(t *testing.T) {
+    templateValues := domain.TemplateValues{
+        TableList: []domain.TableTmplValue{{TableName: "logs", Comment: "The logs.", ColumnList: []domain.ColumnTmplValue{{Name: "message", Comment: "The message."}}}},
+    }
+
+    s, err := New("public", map[string]RuleSetting{
+        ruleTableWithoutPrimaryKey: {Enabled: true, Severity: SeverityWarning},
+    })
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err.Err)
+    }
+
+    findings := s.Run(templateValues)
+    if len(findings) != 1 || findings[0].RuleID != ruleTableWithoutPrimaryKey || findings[0].Severity != SeverityWarning {
+        t.Errorf("expected one table-without-primary-key warning, got %+v", findings)
+    }
+    if HasErrors(findings) {
+        t.Error("expected no error findings")
+    }
+
+    s, err = New("public", map[string]RuleSetting{ruleTableWithoutPrimaryKey: {Enabled: false}})
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err.Err)
+    }
+    if findings := s.Run(templateValues); len(findings) != 0 {
+        t.Errorf("expected no findings for the disabled rule, got %+v", findings)
+    }
+
+    if _, err := New("public", map[string]RuleSetting{"unknown-rule": {Enabled: true}}); err == nil {
+        t.Error("expected an error for the unknown rule")
+    }
+    if _, err := New("public", map[string]RuleSetting{ruleMissingComment: {Enabled: true, Severity: "fatal"}}); err == nil {
+        t.Error("expected an error for the invalid severity")
+    }
+}
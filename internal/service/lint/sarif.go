@@ -0,0 +1,176 @@
+package lint
+
+import (
+    "encoding/json"
+    "strings"
+
+    "github.com/eujoy/data-dict/pkg"
+)
+
+const (
+    sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+    sarifVersion = "2.1.0"
+    toolName     = "data-dict"
+    toolURI      = "https://github.com/eujoy/data-dict"
+)
+
+// sarifLevels maps the severities of the findings to the levels of SARIF results.
+var sarifLevels = map[string]string{
+    SeverityError:   "error",
+    SeverityWarning: "warning",
+    SeverityInfo:    "note",
+}
+
+type sarifLog struct {
+    Schema  string     `json:"$schema"`
+    Version string     `json:"version"`
+    Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+    Tool    sarifTool     `json:"tool"`
+    Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+    Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+    Name           string      `json:"name"`
+    InformationURI string      `json:"informationUri"`
+    Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+    ID                   string                 `json:"id"`
+    ShortDescription     sarifMessage           `json:"shortDescription"`
+    DefaultConfiguration sarifRuleConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifRuleConfiguration struct {
+    Level string `json:"level"`
+}
+
+type sarifMessage struct {
+    Text string `json:"text"`
+}
+
+type sarifResult struct {
+    RuleID    string          `json:"ruleId"`
+    RuleIndex int             `json:"ruleIndex"`
+    Level     string          `json:"level"`
+    Message   sarifMessage    `json:"message"`
+    Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+    PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+    LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+    ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+    Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+    URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+    StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+    FullyQualifiedName string `json:"fullyQualifiedName"`
+    Kind               string `json:"kind"`
+}
+
+// renderSARIF formats the findings as a SARIF 2.1.0 log. Each result points to the migration file which created the
+// object when the migrations have been loaded, as well as to the logical location of the object in the schema.
+func (s *Service) renderSARIF(findings []Finding) (string, *pkg.Error) {
+    var sarifRules []sarifRule
+    ruleIndex := make(map[string]int)
+    for _, r := range rules {
+        setting := s.settings[r.id]
+        if !setting.Enabled {
+            continue
+        }
+
+        ruleIndex[r.id] = len(sarifRules)
+        sarifRules = append(sarifRules, sarifRule{
+            ID:                   r.id,
+            ShortDescription:     sarifMessage{Text: r.description},
+            DefaultConfiguration: sarifRuleConfiguration{Level: sarifLevels[setting.Severity]},
+        })
+    }
+
+    results := []sarifResult{}
+    for _, f := range findings {
+        loc := sarifLocation{
+            LogicalLocations: []sarifLogicalLocation{s.logicalLocation(f)},
+        }
+
+        if s.migrations != nil {
+            if source, ok := s.migrations.locate(f); ok {
+                loc.PhysicalLocation = &sarifPhysicalLocation{
+                    ArtifactLocation: sarifArtifactLocation{URI: source.file},
+                    Region:           sarifRegion{StartLine: source.line},
+                }
+            }
+        }
+
+        results = append(results, sarifResult{
+            RuleID:    f.RuleID,
+            RuleIndex: ruleIndex[f.RuleID],
+            Level:     sarifLevels[f.Severity],
+            Message:   sarifMessage{Text: f.Message},
+            Locations: []sarifLocation{loc},
+        })
+    }
+
+    data, marshalErr := json.MarshalIndent(sarifLog{
+        Schema:  sarifSchema,
+        Version: sarifVersion,
+        Runs: []sarifRun{
+            {
+                Tool: sarifTool{
+                    Driver: sarifDriver{
+                        Name:           toolName,
+                        InformationURI: toolURI,
+                        Rules:          sarifRules,
+                    },
+                },
+                Results: results,
+            },
+        },
+    }, "", "  ")
+    if marshalErr != nil {
+        return "", &pkg.Error{Err: marshalErr}
+    }
+
+    return string(data) + "\n", nil
+}
+
+// logicalLocation returns the schema.table[.column] location of the object the finding refers to.
+func (s *Service) logicalLocation(f Finding) sarifLogicalLocation {
+    if f.Object != "" {
+        return sarifLogicalLocation{
+            FullyQualifiedName: strings.Join([]string{s.schema, f.Table, f.Object}, "."),
+            Kind:               "object",
+        }
+    }
+
+    if f.Column != "" {
+        return sarifLogicalLocation{
+            FullyQualifiedName: strings.Join([]string{s.schema, f.Table, strings.Split(f.Column, ",")[0]}, "."),
+            Kind:               "column",
+        }
+    }
+
+    return sarifLogicalLocation{
+        FullyQualifiedName: strings.Join([]string{s.schema, f.Table}, "."),
+        Kind:               "table",
+    }
+}
@@ -0,0 +1,117 @@
+package lint
+
+import (
+    "encoding/json"
+    "reflect"
+    "testing"
+)
+
+func TestRenderSARIF(t *testing.T) {
+    s, err := New("public", map[string]RuleSetting{
+        ruleMissingComment: {Enabled: false},
+        ruleRedundantIndex: {Enabled: true, Severity: SeverityError},
+    })
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err.Err)
+    }
+    s.migrations = &migrationIndex{
+        tables:  map[string]sourceLocation{"users": {file: "migrations/001.sql", line: 1}},
+        columns: map[string]sourceLocation{},
+        objects: map[string]sourceLocation{"users_email_idx": {file: "migrations/002.sql", line: 4}},
+    }
+
+    findings := []Finding{
+        {RuleID: ruleRedundantIndex, Severity: SeverityError, Table: "users", Column: "email", Object: "users_email_idx", Message: "index 'users_email_idx' is a duplicate"},
+        {RuleID: ruleNamingForeignKeySuffix, Severity: SeverityInfo, Table: "orders", Column: "customer", Message: "foreign key column 'orders.customer' does not have the '_id' suffix"},
+        {RuleID: ruleTableWithoutPrimaryKey, Severity: SeverityError, Table: "users", Message: "table 'users' does not have a primary key"},
+    }
+
+    output, err := s.renderSARIF(findings)
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err.Err)
+    }
+
+    var log sarifLog
+    if unmarshalErr := json.Unmarshal([]byte(output), &log); unmarshalErr != nil {
+        t.Fatalf("unexpected error: %v", unmarshalErr)
+    }
+
+    if log.Version != sarifVersion || log.Schema != sarifSchema || len(log.Runs) != 1 {
+        t.Fatalf("unexpected sarif log header: %+v", log)
+    }
+
+    run := log.Runs[0]
+    if len(run.Tool.Driver.Rules) != len(rules)-1 {
+        t.Errorf("expected %d rules without the disabled one, got %d", len(rules)-1, len(run.Tool.Driver.Rules))
+    }
+    for _, r := range run.Tool.Driver.Rules {
+        if r.ID == ruleMissingComment {
+            t.Errorf("expected the disabled rule not to be listed")
+        }
+        if r.ID == ruleRedundantIndex && r.DefaultConfiguration.Level != "error" {
+            t.Errorf("expected the configured level of the rule, got %v", r.DefaultConfiguration.Level)
+        }
+    }
+
+    expected := []struct {
+        level    string
+        logical  sarifLogicalLocation
+        physical *sarifPhysicalLocation
+    }{
+        {
+            level:    "error",
+            logical:  sarifLogicalLocation{FullyQualifiedName: "public.users.users_email_idx", Kind: "object"},
+            physical: &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: "migrations/002.sql"}, Region: sarifRegion{StartLine: 4}},
+        },
+        {
+            level:    "note",
+            logical:  sarifLogicalLocation{FullyQualifiedName: "public.orders.customer", Kind: "column"},
+            physical: nil,
+        },
+        {
+            level:    "error",
+            logical:  sarifLogicalLocation{FullyQualifiedName: "public.users", Kind: "table"},
+            physical: &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: "migrations/001.sql"}, Region: sarifRegion{StartLine: 1}},
+        },
+    }
+
+    if len(run.Results) != len(expected) {
+        t.Fatalf("expected %d results, got %d", len(expected), len(run.Results))
+    }
+    for i, res := range run.Results {
+        if run.Tool.Driver.Rules[res.RuleIndex].ID != res.RuleID {
+            t.Errorf("expected rule index %d to point to rule %v", res.RuleIndex, res.RuleID)
+        }
+        if res.Level != expected[i].level {
+            t.Errorf("expected level %v, got %v", expected[i].level, res.Level)
+        }
+        if !reflect.DeepEqual(res.Locations[0].LogicalLocations, []sarifLogicalLocation{expected[i].logical}) {
+            t.Errorf("expected logical location %+v, got %+v", expected[i].logical, res.Locations[0].LogicalLocations)
+        }
+        if !reflect.DeepEqual(res.Locations[0].PhysicalLocation, expected[i].physical) {
+            t.Errorf("expected physical location %+v, got %+v", expected[i].physical, res.Locations[0].PhysicalLocation)
+        }
+    }
+}
+
+func TestRenderSARIFWithoutFindings(t *testing.T) {
+    s, err := New("public", nil)
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err.Err)
+    }
+
+    output, err := s.renderSARIF(nil)
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err.Err)
+    }
+
+    var log map[string]interface{}
+    if unmarshalErr := json.Unmarshal([]byte(output), &log); unmarshalErr != nil {
+        t.Fatalf("unexpected error: %v", unmarshalErr)
+    }
+
+    results := log["runs"].([]interface{})[0].(map[string]interface{})["results"]
+    if results == nil || len(results.([]interface{})) != 0 {
+        t.Errorf("expected an empty results array, got %v", results)
+    }
+}
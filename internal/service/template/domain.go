@@ -1,24 +1,138 @@
 package template
 
+// codeFence is the markdown code fence, which cannot be part of the raw string templates.
+const codeFence = "```"
+
+// diagramPanZoomScript adds the zoom controls to the svg diagrams and allows panning them by dragging. Dragging does
+// not follow the links of the tables.
+const diagramPanZoomScript = `
+            (function () {
+                document.querySelectorAll(".diagram-container").forEach(function (container) {
+                    var svg = container.querySelector("svg.diagram");
+                    if (!svg) {
+                        return;
+                    }
+
+                    var initial = svg.getAttribute("viewBox").split(" ").map(Number);
+                    var view = initial.slice();
+                    var drag = null;
+                    var dragged = false;
+
+                    svg.setAttribute("width", "100%");
+                    svg.setAttribute("height", Math.min(initial[3], 600));
+
+                    function update() {
+                        svg.setAttribute("viewBox", view.join(" "));
+                    }
+
+                    function zoom(factor, cx, cy) {
+                        cx = cx === undefined ? view[0] + view[2] / 2 : cx;
+                        cy = cy === undefined ? view[1] + view[3] / 2 : cy;
+                        view = [cx - (cx - view[0]) * factor, cy - (cy - view[1]) * factor, view[2] * factor, view[3] * factor];
+                        update();
+                    }
+
+                    var controls = document.createElement("div");
+                    controls.className = "diagram-controls";
+                    [["+", function () { zoom(0.8); }], ["-", function () { zoom(1.25); }], ["reset", function () { view = initial.slice(); update(); }]].forEach(function (control) {
+                        var button = document.createElement("button");
+                        button.textContent = control[0];
+                        button.addEventListener("click", control[1]);
+                        controls.appendChild(button);
+                    });
+                    container.appendChild(controls);
+
+                    svg.addEventListener("wheel", function (event) {
+                        event.preventDefault();
+                        var rect = svg.getBoundingClientRect();
+                        var cx = view[0] + (event.clientX - rect.left) / rect.width * view[2];
+                        var cy = view[1] + (event.clientY - rect.top) / rect.height * view[3];
+                        zoom(event.deltaY < 0 ? 0.9 : 1.1, cx, cy);
+                    });
+
+                    svg.addEventListener("mousedown", function (event) {
+                        drag = { x: event.clientX, y: event.clientY, view: view.slice() };
+                        dragged = false;
+                    });
+
+                    window.addEventListener("mousemove", function (event) {
+                        if (!drag) {
+                            return;
+                        }
+
+                        var rect = svg.getBoundingClientRect();
+                        var dx = (event.clientX - drag.x) / rect.width * view[2];
+                        var dy = (event.clientY - drag.y) / rect.height * view[3];
+                        dragged = dragged || Math.abs(event.clientX - drag.x) + Math.abs(event.clientY - drag.y) > 3;
+                        view = [drag.view[0] - dx, drag.view[1] - dy, view[2], view[3]];
+                        update();
+                    });
+
+                    window.addEventListener("mouseup", function () {
+                        drag = null;
+                    });
+
+                    svg.addEventListener("click", function (event) {
+                        if (dragged) {
+                            event.preventDefault();
+                            dragged = false;
+                        }
+                    }, true);
+                });
+            })();
+        `
+
 var (
     dataDirectoryTemplateMermaid = `erDiagram
 	{{- range .TableList }}
+	{{- if eq $.DiagramDetail "names" }}
+	{{ .TableName }}
+	{{- else }}
 	{{ .TableName }} {
 	{{- range .ColumnList }}
-		{{ .DataType }} {{ .Name }} "{{ if .PK }}PK{{ end }}{{ if .FK }}{{ if .PK }}_{{ end }}FK{{ end }}"
+	{{- if or (eq $.DiagramDetail "full") .PK .FK .InferredFK .UQ }}
+		{{ diagramType .DataType }} {{ .Name }} "{{ if .PK }}PK{{ end }}{{ if .FK }}{{ if .PK }}_{{ end }}FK{{ end }}"
+	{{- end }}
 	{{- end }}
 	}
+	{{- end }}
 	{{ end }}
     %% ----- Relationships ----
     {{ range .TableList }}
     {{- $tableName := .TableName }}
     {{- range .ConstraintsList }}
     {{- if .ReferencesTable }}
+    {{- if .Inferred }}
+    %% {{ $tableName }} }o..o{ {{ .ReferencesTable }} : "{{ $tableName }}.{{ .Column }} is inferred to relate to {{ .ReferencesTable }}.{{ .ReferencesColumn }}"
+    {{ $tableName }} }o..o{ {{ .ReferencesTable }} : "{{ .Column }} to {{ .ReferencesColumn }} (inferred)"
+    {{- else }}
     %% {{ $tableName }} }o--o{ {{ .ReferencesTable }} : "{{ $tableName }}.{{ .Column }} relates to {{ .ReferencesTable }}.{{ .ReferencesColumn }}"
     {{ $tableName }} }o--o{ {{ .ReferencesTable }} : "{{ .Column }} to {{ .ReferencesColumn }}"
     {{- end }}
     {{- end }}
     {{- end }}
+    {{- end }}
+
+    %% ----- Inheritance ----
+    {{ range .TableList }}
+    {{- $tableName := .TableName }}
+    {{- range .InheritsFrom }}
+    %% {{ $tableName }} inherits from {{ . }}
+    {{ $tableName }} ||..|| {{ . }} : "inherits"
+    {{- end }}
+    {{- end }}
+
+    %% ----- Lineage ----
+    {{ range .ViewList }}
+    {{- $viewName := .Name }}
+    %% {{ $viewName }} is a {{ .Kind }}
+    {{ $viewName }}
+    {{- range .DependsOn }}
+    {{- if .Shown }}
+    {{ $viewName }} }o..|| {{ .Name }} : "depends on"
+    {{- end }}
+    {{- end }}
+    {{- end }}
 `
 
     dataDirectoryTemplateERDiagram = `# ER Diagram Definition
@@ -29,9 +143,13 @@ title {label:"{{ .DatabaseName }}"}
 
 {{- range .TableList }}
 [{{ .TableName }}]
+{{- if ne $.DiagramDetail "names" }}
 {{- range .ColumnList }}
+{{- if or (eq $.DiagramDetail "full") .PK .FK .InferredFK .UQ }}
 	{{ if .PK }}*{{ end }}{{ if .FK }}+{{ end }}{{ .Name }} {label:"{{ .DataType }}"}
 {{- end }}
+{{- end }}
+{{- end }}
 {{ end }}
 # -----
 
@@ -40,9 +158,23 @@ title {label:"{{ .DatabaseName }}"}
 {{ range .TableList }}
 {{- $tableName := .TableName }}
 {{- range .ConstraintsList }}
-{{- if .ReferencesTable }}{{ $tableName }} *--* {{ .ReferencesTable }} {label:"{{ $tableName }}.{{ .Column }} relates to {{ .ReferencesTable }}.{{ .ReferencesColumn }}"}{{print "\n"}}{{- end }}
+{{- if .ReferencesTable }}{{ $tableName }} *--* {{ .ReferencesTable }} {label:"{{ $tableName }}.{{ .Column }} {{ if .Inferred }}is inferred to relate{{ else }}relates{{ end }} to {{ .ReferencesTable }}.{{ .ReferencesColumn }}"}{{print "\n"}}{{- end }}
 {{- end }}
 {{- end }}
+
+# Definition of inheritance.
+
+{{ range .TableList }}
+{{- $tableName := .TableName }}
+{{- range .InheritsFrom }}{{ $tableName }} 1--1 {{ . }} {label:"{{ $tableName }} inherits from {{ . }}", color:"#1f6feb"}{{print "\n"}}{{- end }}
+{{- end }}
+
+# Definition of views and their lineage.
+
+{{ range .ViewList }}
+{{- $viewName := .Name }}[{{ $viewName }}] {bgcolor:"#e2d9f3"}{{print "\n"}}
+{{- range .DependsOn }}{{ if .Shown }}{{ $viewName }} *--1 {{ .Name }} {label:"{{ $viewName }} depends on {{ .Name }}"}{{print "\n"}}{{ end }}{{- end }}
+{{- end }}
 `
 
     dataDirectoryTemplateMarkdown = `# Data Directory
@@ -50,10 +182,97 @@ title {label:"{{ .DatabaseName }}"}
 Database: {{ .DatabaseName }}
 
 Table of contents
-{{ range .TableList }}
+{{ if .SubjectAreaList }}
+* [Subject Areas](#subject-areas)
+{{- range .SubjectAreaList }}
+  * [Subject Area: {{ .Name }}](#{{ .ID }})
+{{- end }}
+{{- end }}
+{{- range .TableList }}
 * [Table: {{ .TableName }}](#table-{{ .TableName }})
   * [Field Details](#field-details-{{ .TableName }})
+  {{- if $.Profiled }}
+  * [Profile](#profile-{{ .TableName }})
+  {{- end }}
   * [Constraints](#constraints-{{ .TableName }})
+  {{- if .ReferencedBy }}
+  * [Referenced by](#referenced-by-{{ .TableName }})
+  {{- end }}
+  {{- if .TriggerList }}
+  * [Triggers](#triggers-{{ .TableName }})
+  {{- end }}
+  {{- if or .RLSEnabled .GrantList .PolicyList }}
+  * [Security](#security-{{ .TableName }})
+  {{- end }}
+{{- end }}
+{{- if .ViewList }}
+* [Views](#views)
+{{- end }}
+{{- if .DomainList }}
+* [Domains](#domains)
+{{- end }}
+{{- if .CustomTypeList }}
+* [Types](#types)
+{{- range .CustomTypeList }}
+  * [Type: {{ .Name }}](#type-{{ .Name }})
+{{- end }}
+{{- end }}
+{{- if .RoutineList }}
+* [Routines](#routines)
+{{- end }}
+{{- if .SequenceList }}
+* [Sequences](#sequences)
+{{- end }}
+{{- if .SubjectAreaList }}
+
+## Subject Areas
+
+` + codeFence + `mermaid
+flowchart LR
+{{- range .SubjectAreaList }}
+    {{ .ID }}["{{ .Name }}"]
+{{- end }}
+{{- range .AreaLinkList }}
+    {{ .FromID }} -->|"{{ .Relationships }} relationship(s)"| {{ .ToID }}
+{{- end }}
+` + codeFence + `
+{{- range .SubjectAreaList }}
+
+<a id="{{ .ID }}"></a>
+### Subject Area: {{ .Name }}
+{{- if .Description }}
+
+{{ .Description }}
+{{- end }}
+
+Tables: {{ range $i, $table := .TableList }}{{ if $i }}, {{ end }}[{{ $table.TableName }}](#table-{{ $table.TableName }}){{ end }}
+
+` + codeFence + `mermaid
+erDiagram
+{{- range .TableList }}
+{{- if eq $.DiagramDetail "names" }}
+    {{ .TableName }}
+{{- else }}
+    {{ .TableName }} {
+    {{- range .ColumnList }}
+    {{- if or (eq $.DiagramDetail "full") .PK .FK .InferredFK .UQ }}
+        {{ diagramType .DataType }} {{ .Name }} "{{ if .PK }}PK{{ end }}{{ if .FK }}{{ if .PK }}_{{ end }}FK{{ end }}"
+    {{- end }}
+    {{- end }}
+    }
+{{- end }}
+{{- end }}
+{{- range .RelationshipList }}
+    {{ .Table }} }o{{ if .Inferred }}..{{ else }}--{{ end }}o{ {{ .ReferencesTable }} : "{{ .Column }} to {{ .ReferencesColumn }}{{ if .Inferred }} (inferred){{ end }}{{ if .StubTable }} ({{ .StubTable }} in {{ .StubArea }}){{ end }}"
+{{- end }}
+{{- range .TableList }}
+{{- $tableName := .TableName }}
+{{- range .InheritsFrom }}
+    {{ $tableName }} ||..|| {{ . }} : "inherits"
+{{- end }}
+{{- end }}
+` + codeFence + `
+{{- end }}
 {{- end }}
 
 ----
@@ -61,13 +280,63 @@ Table of contents
 {{- range .TableList }}
 
 ## Table: {{ .TableName }}
+{{- if .SubjectArea }}
+
+Subject area: [{{ .SubjectArea }}](#{{ .SubjectAreaID }})
+{{- end }}
+{{- if .Tags }}
+
+Tags: {{ range $i, $tag := .Tags }}{{ if $i }} {{ end }}<kbd>{{ $tag }}</kbd>{{ end }}
+{{- end }}
+{{- if .UsedByViews }}
+
+Used by views: {{ range $i, $view := .UsedByViews }}{{ if $i }}, {{ end }}[{{ $view }}](#view-{{ $view }}){{ end }}
+{{- end }}
+{{- if .InheritsFrom }}
+
+Inherits from: {{ range $i, $parent := .InheritsFrom }}{{ if $i }}, {{ end }}[{{ $parent }}](#table-{{ $parent }}){{ end }}
+{{- end }}
+{{- if .InheritedBy }}
+
+Inherited by: {{ range $i, $child := .InheritedBy }}{{ if $i }}, {{ end }}[{{ $child }}](#table-{{ $child }}){{ end }}
+{{- end }}
+{{- with .Partitioning }}
+{{- if .PartitionOf }}
+
+Partition of: {{ if .PartitionOfShown }}[{{ .PartitionOf }}](#table-{{ .PartitionOf }}){{ else }}{{ .PartitionOf }}{{ end }} {{ .Bound }}
+{{- end }}
+{{- if .Strategy }}
+
+Partitioned by: {{ .Strategy }} {{ .Key }}
+{{- end }}
+{{- end }}
+{{- if .PartitionList }}
+
+| Partition | Bound |
+| :-------- | :---- |
+{{- range .PartitionList }}
+| {{ if .Shown }}[{{ .Name }}](#table-{{ .Name }}){{ else }}{{ .Name }}{{ end }} | {{ .Bound }} |
+{{- end }}
+{{- end }}
 
 ### Field Details: {{ .TableName }}
 
-| #   | Name | Data Type | PK  | FK  | UQ  | Not null | Default Value | Description |
-| :-: | :--- | :-------- | :-: | :-: | :-: | :------: | :------------ | :---------- |
+| #   | Name | Data Type | PK  | FK  | UQ  | Not null | Default Value | Description |{{ if $.Sampled }} Examples |{{ end }}
+| :-: | :--- | :-------- | :-: | :-: | :-: | :------: | :------------ | :---------- |{{ if $.Sampled }} :------- |{{ end }}
 {{- range .ColumnList }}
-| {{ .Ordinal }} | {{ .Name }} | {{ .DataType }} | {{ if .PK }}:heavy_check_mark:{{ end }} | {{ if .FK }}:heavy_check_mark:{{ end }} | {{ if .UQ }}:heavy_check_mark:{{ end }} | {{ if .NotNull }}:heavy_check_mark:{{ end }} | {{ .DefaultValue }} | {{ .Comment }} |
+| {{ .Ordinal }} | {{ .Name }}{{ range .Tags }} <kbd>{{ . }}</kbd>{{ end }} | {{ if .Domain }}[{{ .DataType }}](#domain-{{ .Domain }}){{ else if .CustomType }}[{{ .DataType }}](#type-{{ .CustomType }}){{ else }}{{ .DataType }}{{ end }} | {{ if .PK }}:heavy_check_mark:{{ end }} | {{ if .FK }}:heavy_check_mark:{{ end }} | {{ if .UQ }}:heavy_check_mark:{{ end }} | {{ if .NotNull }}:heavy_check_mark:{{ end }} | {{ if .Identity }}identity ({{ .Identity }}){{ else if .Generated }}generated: {{ .Generated }}{{ else }}{{ .DefaultValue }}{{ end }}{{ if .Sequence }} [{{ .Sequence }}](#sequence-{{ .Sequence }}){{ end }} | {{ .Comment }} |{{ if $.Sampled }} {{ if .ExamplesRedacted }}_redacted_{{ else if .ExamplesUnavailable }}_unavailable_{{ else }}{{ range $i, $example := .Examples }}{{ if $i }}, {{ end }}{{ $example }}{{ end }}{{ end }} |{{ end }}
+{{- end }}
+{{- if $.Profiled }}
+
+### Profile: {{ .TableName }}
+
+Estimated rows: {{ .EstimatedRows }}
+
+| Name | Null fraction | Distinct values | Most common values | Histogram bounds |
+| :--- | ------------: | --------------: | :----------------- | :--------------- |
+{{- range .ColumnList }}
+| {{ .Name }} | {{ with .Profile }}{{ .NullFraction }} | {{ .DistinctValues }} | {{ .MostCommonValues }} | {{ .HistogramBounds }}{{ else }} | | |{{ end }} |
+{{- end }}
 {{- end }}
 
 ### Constraints: {{ .TableName }}
@@ -75,7 +344,129 @@ Table of contents
 | Name | Type | Column(s) | References |
 | :--- | :--- | :-------- | :--------- |
 {{- range .ConstraintsList }}
-| {{ .Name }} | {{ .Type }} | {{ .Column }} | {{ if .ReferencesTable }}[{{ .ReferencesTable }}.{{ .ReferencesColumn }}](#table-{{ .ReferencesTable }}){{ end }} |
+| {{ .Name }} | {{ .Type }}{{ if .Inferred }} (inferred){{ end }} | {{ .Column }} | {{ if .ReferencesTable }}[{{ .ReferencesTable }}.{{ .ReferencesColumn }}](#table-{{ .ReferencesTable }}){{ end }} |
+{{- end }}
+{{- if .ReferencedBy }}
+
+### Referenced by: {{ .TableName }}
+
+| Table | Column | Referenced column | Constraint |
+| :---- | :----- | :---------------- | :--------- |
+{{- range .ReferencedBy }}
+| [{{ .Table }}](#table-{{ .Table }}) | {{ .Column }} | {{ .ReferencedColumn }} | {{ .Name }}{{ if .Inferred }} (inferred){{ end }} |
+{{- end }}
+{{- end }}
+{{- if .TriggerList }}
+
+### Triggers: {{ .TableName }}
+
+| Name | Timing | Events | Level | Enabled | Function | When | Definition |
+| :--- | :----- | :----- | :---- | :------ | :------- | :--- | :--------- |
+{{- range .TriggerList }}
+| {{ .Name }} | {{ .Timing }} | {{ range $i, $event := .Events }}{{ if $i }}, {{ end }}{{ $event }}{{ end }} | {{ .Level }} | {{ .Enabled }} | {{ if .RoutineID }}[{{ .Function }}](#{{ .RoutineID }}){{ else }}{{ .Function }}{{ end }} | {{ .When }} | {{ .Definition }} |
+{{- end }}
+{{- end }}
+{{- if or .RLSEnabled .GrantList .PolicyList }}
+
+### Security: {{ .TableName }}
+
+Row level security: {{ if .RLSEnabled }}enabled{{ if .RLSForced }} (forced for the table owner too){{ end }}{{ else }}disabled{{ end }}
+{{- if .GrantList }}
+
+| Grantee | Privilege | Column | Grantable |
+| :------ | :-------- | :----- | :-------: |
+{{- range .GrantList }}
+| {{ .Grantee }} | {{ .Privilege }} | {{ .Column }} | {{ if .Grantable }}:heavy_check_mark:{{ end }} |
+{{- end }}
+{{- end }}
+{{- if .PolicyList }}
+
+| Policy | Type | Command | Roles | Using | With check |
+| :----- | :--- | :------ | :---- | :---- | :--------- |
+{{- range .PolicyList }}
+| {{ .Name }} | {{ .Permissive }} | {{ .Command }} | {{ range $i, $role := .Roles }}{{ if $i }}, {{ end }}{{ $role }}{{ end }} | {{ .Using }} | {{ .WithCheck }} |
+{{- end }}
+{{- end }}
+{{- end }}
+
+[Top :top:](#data-directory)
+{{- end }}
+{{- if .ViewList }}
+
+## Views
+
+| Name | Kind | Depends on | Description |
+| :--- | :--- | :--------- | :---------- |
+{{- range .ViewList }}
+| <a id="view-{{ .Name }}"></a>{{ .Name }} | {{ .Kind }} | {{ range $i, $dep := .DependsOn }}{{ if $i }}<br>{{ end }}{{ if not $dep.Shown }}{{ $dep.Name }}{{ else if eq $dep.Kind "TABLE" }}[{{ $dep.Name }}](#table-{{ $dep.Name }}){{ else }}[{{ $dep.Name }}](#view-{{ $dep.Name }}){{ end }}{{ if $dep.Columns }} ({{ range $j, $column := $dep.Columns }}{{ if $j }}, {{ end }}{{ $column }}{{ end }}){{ end }}{{ end }} | {{ .Comment }} |
+{{- end }}
+
+[Top :top:](#data-directory)
+{{- end }}
+{{- if .DomainList }}
+
+## Domains
+
+| Name | Base Type | Not null | Default Value | Checks | Description |
+| :--- | :-------- | :------: | :------------ | :----- | :---------- |
+{{- range .DomainList }}
+| <a id="domain-{{ .Name }}"></a>{{ .Name }} | {{ .BaseType }} | {{ if .NotNull }}:heavy_check_mark:{{ end }} | {{ .DefaultValue }} | {{ range $i, $check := .CheckList }}{{ if $i }}<br>{{ end }}{{ $check.Definition }}{{ end }} | {{ .Comment }} |
+{{- end }}
+
+[Top :top:](#data-directory)
+{{- end }}
+{{- if .CustomTypeList }}
+
+## Types
+{{- range .CustomTypeList }}
+
+<a id="type-{{ .Name }}"></a>
+### Type: {{ .Name }}
+{{- if .Comment }}
+
+{{ .Comment }}
+{{- end }}
+
+| #   | Name | Data Type |
+| :-: | :--- | :-------- |
+{{- range .AttributeList }}
+| {{ .Ordinal }} | {{ .Name }} | {{ .DataType }} |
+{{- end }}
+{{- end }}
+
+[Top :top:](#data-directory)
+{{- end }}
+{{- if .RoutineList }}
+
+## Routines
+{{- range .RoutineList }}
+
+<a id="{{ .ID }}"></a>
+### {{ .Kind }}: {{ .Signature }}
+
+| Returns | Language | Volatility | Security definer |
+| :------ | :------- | :--------- | :--------------: |
+| {{ .ReturnType }} | {{ .Language }} | {{ .Volatility }} | {{ if .SecurityDefiner }}:heavy_check_mark:{{ end }} |
+{{- if .Comment }}
+
+{{ .Comment }}
+{{- end }}
+{{- if .Body }}
+
+<pre><code>{{ .Body }}</code></pre>
+{{- end }}
+{{- end }}
+
+[Top :top:](#data-directory)
+{{- end }}
+{{- if .SequenceList }}
+
+## Sequences
+
+| Name | Data Type | Start | Increment | Min | Max | Cycle | Owned by |
+| :--- | :-------- | ----: | --------: | --: | --: | :---: | :------- |
+{{- range .SequenceList }}
+| <a id="sequence-{{ .Name }}"></a>{{ .Name }} | {{ .DataType }} | {{ .StartValue }} | {{ .IncrementBy }} | {{ .MinValue }} | {{ .MaxValue }} | {{ if .Cycle }}:heavy_check_mark:{{ end }} | {{ if .OwnedByTable }}[{{ .OwnedByTable }}.{{ .OwnedByColumn }}](#table-{{ .OwnedByTable }}){{ end }} |
 {{- end }}
 
 [Top :top:](#data-directory)
@@ -118,6 +509,52 @@ Table of contents
                 border-bottom: 2px solid #009879;
             }
 
+            .badge {
+                display: inline-block;
+                padding: 1px 6px;
+                margin-left: 4px;
+                border-radius: 8px;
+                font-size: 0.75em;
+                color: #ffffff;
+                background-color: #6c757d;
+            }
+
+            .badge-pii {
+                background-color: #c0392b;
+            }
+
+            .badge-sensitive {
+                background-color: #d68910;
+            }
+
+            .search-bar {
+                position: sticky;
+                top: 0;
+                padding: 10px 0;
+                background-color: #ffffff;
+            }
+
+            .search {
+                width: 400px;
+                padding: 8px;
+                margin-right: 12px;
+                font-size: 1em;
+            }
+
+            .diagram-container {
+                position: relative;
+                max-height: 600px;
+                overflow: hidden;
+                border: 1px solid #dddddd;
+                cursor: grab;
+            }
+
+            .diagram-controls {
+                position: absolute;
+                top: 8px;
+                right: 8px;
+            }
+
             .color-with-pseudo {
                 list-style: none;
                 list-style-position: inside;
@@ -140,21 +577,143 @@ Table of contents
         
         <h2 id="top">Table of contents</h2>
         <ul class="color-with-pseudo">
+            <li><a href="#diagram">Diagram</a></li>
+        {{- if .SubjectAreaList }}
+            <li><a href="#subject-areas">Subject Areas</a>
+                <ul class="color-with-pseudo">
+                {{- range .SubjectAreaList }}
+                    <li><a href="#{{ .ID }}">Subject Area: {{ .Name }}</a></li>
+                {{- end }}
+                </ul>
+            </li>
+        {{- end }}
         {{- range .TableList }}
-            <li><a href="#table-{{ .TableName }}">Table: {{ .TableName }}</a></li>
+            <li class="toc-table" data-table="{{ .TableName }}"><a href="#table-{{ .TableName }}">Table: {{ .TableName }}</a>
                 <ul class="color-with-pseudo">
                     <li><a href="#field-details-{{ .TableName }}">Field Details</a></li>
+                    {{- if $.Profiled }}
+                    <li><a href="#profile-{{ .TableName }}">Profile</a></li>
+                    {{- end }}
                     <li><a href="#constraints-{{ .TableName }}">Constraints</a></li>
+                    {{- if .ReferencedBy }}
+                    <li><a href="#referenced-by-{{ .TableName }}">Referenced by</a></li>
+                    {{- end }}
+                    {{- if .TriggerList }}
+                    <li><a href="#triggers-{{ .TableName }}">Triggers</a></li>
+                    {{- end }}
+                    {{- if or .RLSEnabled .GrantList .PolicyList }}
+                    <li><a href="#security-{{ .TableName }}">Security</a></li>
+                    {{- end }}
+                </ul>
+            </li>
+        {{- end }}
+        {{- if .ViewList }}
+            <li><a href="#views">Views</a></li>
+        {{- end }}
+        {{- if .DomainList }}
+            <li><a href="#domains">Domains</a></li>
+        {{- end }}
+        {{- if .CustomTypeList }}
+            <li><a href="#types">Types</a>
+                <ul>
+                {{- range .CustomTypeList }}
+                    <li><a href="#type-{{ .Name }}">{{ .Name }}</a></li>
+                {{- end }}
                 </ul>
             </li>
         {{- end }}
+        {{- if .RoutineList }}
+            <li><a href="#routines">Routines</a></li>
+        {{- end }}
+        {{- if .SequenceList }}
+            <li><a href="#sequences">Sequences</a></li>
+        {{- end }}
         </ul>
         
+        <h2 id="diagram">Diagram</h2>
+        
+        <div class="diagram-container">{{ diagram .TableList .ViewList .DiagramDetail }}</div>
+        {{- if .SubjectAreaList }}
+        
+        <h2 id="subject-areas">Subject Areas</h2>
+        
+        <div class="diagram-container">{{ areaOverviewDiagram .SubjectAreaList .AreaLinkList }}</div>
+        {{- range .SubjectAreaList }}
+        
+        <h3 id="{{ .ID }}">Subject Area: {{ .Name }}</h3>
+        {{- if .Description }}
+        
+        <p>{{ .Description }}</p>
+        {{- end }}
+        
+        <p>Tables: {{ range $i, $table := .TableList }}{{ if $i }}, {{ end }}<a href="#table-{{ $table.TableName }}">{{ $table.TableName }}</a>{{ end }}</p>
+        
+        <div class="diagram-container">{{ areaDiagram . $.DiagramDetail }}</div>
+        {{- end }}
+        
+        <a href="#top">[Top &#x21a5;]</a>
+        {{- end }}
+        
         <br/>
         
+        <div class="search-bar">
+            <input id="search" class="search" type="search" placeholder="Search tables and columns by name, type, comment or tag...">
+            <label><input id="filter-pk" type="checkbox"> PK</label>
+            <label><input id="filter-fk" type="checkbox"> FK</label>
+            <label><input id="filter-nullable" type="checkbox"> Nullable</label>
+            <span id="search-count"></span>
+        </div>
+        
         {{- range .TableList }}
         
-        <h2 id="table-{{ .TableName }}">Table: {{ .TableName }}</h2>
+        <div class="table-section" data-table="{{ .TableName }}" data-search="{{ .TableName }} {{ .Comment }} {{ .SubjectArea }}{{ range .Tags }} {{ . }}{{ end }}">
+        
+        <h2 id="table-{{ .TableName }}">Table: {{ .TableName }}{{ range .Tags }}<span class="badge">{{ . }}</span>{{ end }}</h2>
+        {{- if .SubjectArea }}
+        
+        <p>Subject area: <a href="#{{ .SubjectAreaID }}">{{ .SubjectArea }}</a></p>
+        {{- end }}
+        {{- if .UsedByViews }}
+        
+        <p>Used by views: {{ range $i, $view := .UsedByViews }}{{ if $i }}, {{ end }}<a href="#view-{{ $view }}">{{ $view }}</a>{{ end }}</p>
+        {{- end }}
+        {{- if .InheritsFrom }}
+        
+        <p>Inherits from: {{ range $i, $parent := .InheritsFrom }}{{ if $i }}, {{ end }}<a href="#table-{{ $parent }}">{{ $parent }}</a>{{ end }}</p>
+        {{- end }}
+        {{- if .InheritedBy }}
+        
+        <p>Inherited by: {{ range $i, $child := .InheritedBy }}{{ if $i }}, {{ end }}<a href="#table-{{ $child }}">{{ $child }}</a>{{ end }}</p>
+        {{- end }}
+        {{- with .Partitioning }}
+        {{- if .PartitionOf }}
+        
+        <p>Partition of: {{ if .PartitionOfShown }}<a href="#table-{{ .PartitionOf }}">{{ .PartitionOf }}</a>{{ else }}{{ .PartitionOf }}{{ end }} <code>{{ .Bound }}</code></p>
+        {{- end }}
+        {{- if .Strategy }}
+        
+        <p>Partitioned by: {{ .Strategy }} <code>{{ .Key }}</code></p>
+        {{- end }}
+        {{- end }}
+        {{- if .PartitionList }}
+        
+        <table class="styled-table">
+            <thead>
+                <tr>
+                    <th>Partition</th>
+                    <th>Bound</th>
+                </tr>
+            </thead>
+        {{- range .PartitionList }}
+            <tbody>
+                <tr>
+                    <td style="text-align:left">{{ if .Shown }}<a href="#table-{{ .Name }}">{{ .Name }}</a>{{ else }}{{ .Name }}{{ end }}</td>
+                    <td style="text-align:left"><code>{{ .Bound }}</code></td>
+                </tr>
+            </tbody>
+        {{- end }}
+        </table>
+        {{- end }}
         
         <h3 id="field-details-{{ .TableName }}">Field Details: {{ .TableName }}</h3>
         
@@ -170,24 +729,64 @@ Table of contents
                     <th>Not null</th>
                     <th>Default Value</th>
                     <th>Description</th>
+                    {{- if $.Sampled }}
+                    <th>Examples</th>
+                    {{- end }}
                 </tr>
             </thead>
         {{- range .ColumnList }}
             <tbody>
-                <tr>
+                <tr class="column-row" data-search="{{ .Name }} {{ .DataType }} {{ .Comment }}{{ range .Tags }} {{ . }}{{ end }}" data-pk="{{ .PK }}" data-fk="{{ .FK }}" data-nullable="{{ not .NotNull }}">
                     <td style="text-align:center">{{ .Ordinal }}</td>
-                    <td style="text-align:left">{{ .Name }}</td>
-                    <td style="text-align:left">{{ .DataType }}</td>
+                    <td style="text-align:left">{{ .Name }}{{ range .Tags }}<span class="badge{{ if eq . "pii" }} badge-pii{{ else if eq . "sensitive" }} badge-sensitive{{ end }}">{{ . }}</span>{{ end }}</td>
+                    <td style="text-align:left">{{ if .Domain }}<a href="#domain-{{ .Domain }}">{{ .DataType }}</a>{{ else if .CustomType }}<a href="#type-{{ .CustomType }}">{{ .DataType }}</a>{{ else }}{{ .DataType }}{{ end }}</td>
                     <td style="text-align:center">{{ if .PK }}&#x2714;{{ end }}</td>
                     <td style="text-align:center">{{ if .FK }}&#x2714;{{ end }}</td>
                     <td style="text-align:center">{{ if .UQ }}&#x2714;{{ end }}</td>
                     <td style="text-align:center">{{ if .NotNull }}&#x2714;{{ end }}</td>
-                    <td style="text-align:left">{{ .DefaultValue }}</td>
+                    <td style="text-align:left">{{ if .Identity }}<span class="badge">identity</span> {{ .Identity }}{{ else if .Generated }}<span class="badge">generated</span> <code>{{ .Generated }}</code>{{ else }}{{ .DefaultValue }}{{ end }}{{ if .Sequence }} (<a href="#sequence-{{ .Sequence }}">{{ .Sequence }}</a>){{ end }}</td>
                     <td style="text-align:left">{{ .Comment }}</td>
+                    {{- if $.Sampled }}
+                    <td style="text-align:left">{{ if .ExamplesRedacted }}<i>redacted</i>{{ else if .ExamplesUnavailable }}<i>unavailable</i>{{ else }}{{ range $i, $example := .Examples }}{{ if $i }}, {{ end }}<code>{{ $example }}</code>{{ end }}{{ end }}</td>
+                    {{- end }}
                 </tr>
             </tbody>
         {{- end }}
         </table>
+        {{- if $.Profiled }}
+        
+        <h3 id="profile-{{ .TableName }}">Profile: {{ .TableName }}</h3>
+        
+        <details>
+            <summary>Estimated rows: {{ .EstimatedRows }}</summary>
+            <table class="styled-table">
+                <thead>
+                    <tr>
+                        <th>Name</th>
+                        <th>Null fraction</th>
+                        <th>Distinct values</th>
+                        <th>Most common values</th>
+                        <th>Histogram bounds</th>
+                    </tr>
+                </thead>
+            {{- range .ColumnList }}
+                <tbody>
+                    <tr>
+                        <td style="text-align:left">{{ .Name }}</td>
+                        {{- with .Profile }}
+                        <td style="text-align:right">{{ .NullFraction }}</td>
+                        <td style="text-align:right">{{ .DistinctValues }}</td>
+                        <td style="text-align:left">{{ .MostCommonValues }}</td>
+                        <td style="text-align:left">{{ .HistogramBounds }}</td>
+                        {{- else }}
+                        <td colspan="4" style="text-align:left">No statistics available.</td>
+                        {{- end }}
+                    </tr>
+                </tbody>
+            {{- end }}
+            </table>
+        </details>
+        {{- end }}
         
         <h3 id="constraints-{{ .TableName }}">Constraints: {{ .TableName }}</h3>
         
@@ -204,16 +803,987 @@ Table of contents
             <tbody>
                 <tr>
                     <td style="text-align:left">{{ .Name }}</td>
-                    <td style="text-align:left">{{ .Type }}</td>
+                    <td style="text-align:left">{{ .Type }}{{ if .Inferred }} <i>(inferred)</i>{{ end }}</td>
                     <td style="text-align:left">{{ .Column }}</td>
                     <td style="text-align:left">{{ if .ReferencesTable }}<a href="#table-{{ .ReferencesTable }}">{{ .ReferencesTable }}.{{ .ReferencesColumn }}</a>{{ end }}</td>
                 </tr>
             </tbody>
         {{- end }}
         </table>
+        {{- if .ReferencedBy }}
+        
+        <h3 id="referenced-by-{{ .TableName }}">Referenced by: {{ .TableName }}</h3>
+        
+        <table class="styled-table">
+            <thead>
+                <tr>
+                    <th>Table</th>
+                    <th>Column</th>
+                    <th>Referenced column</th>
+                    <th>Constraint</th>
+                </tr>
+            </thead>
+        {{- range .ReferencedBy }}
+            <tbody>
+                <tr>
+                    <td style="text-align:left"><a href="#table-{{ .Table }}">{{ .Table }}</a></td>
+                    <td style="text-align:left">{{ .Column }}</td>
+                    <td style="text-align:left">{{ .ReferencedColumn }}</td>
+                    <td style="text-align:left">{{ .Name }}{{ if .Inferred }} <i>(inferred)</i>{{ end }}</td>
+                </tr>
+            </tbody>
+        {{- end }}
+        </table>
+        {{- end }}
+        {{- if .TriggerList }}
+        
+        <h3 id="triggers-{{ .TableName }}">Triggers: {{ .TableName }}</h3>
+        
+        <table class="styled-table">
+            <thead>
+                <tr>
+                    <th>Name</th>
+                    <th>Timing</th>
+                    <th>Events</th>
+                    <th>Level</th>
+                    <th>Enabled</th>
+                    <th>Function</th>
+                    <th>When</th>
+                    <th>Definition</th>
+                </tr>
+            </thead>
+        {{- range .TriggerList }}
+            <tbody>
+                <tr>
+                    <td style="text-align:left">{{ .Name }}</td>
+                    <td style="text-align:left">{{ .Timing }}</td>
+                    <td style="text-align:left">{{ range $i, $event := .Events }}{{ if $i }}, {{ end }}{{ $event }}{{ end }}</td>
+                    <td style="text-align:left">{{ .Level }}</td>
+                    <td style="text-align:left">{{ .Enabled }}</td>
+                    <td style="text-align:left">{{ if .RoutineID }}<a href="#{{ .RoutineID }}">{{ .Function }}</a>{{ else }}{{ .Function }}{{ end }}</td>
+                    <td style="text-align:left">{{ if .When }}<code>{{ .When }}</code>{{ end }}</td>
+                    <td style="text-align:left"><code>{{ .Definition }}</code></td>
+                </tr>
+            </tbody>
+        {{- end }}
+        </table>
+        {{- end }}
+        {{- if or .RLSEnabled .GrantList .PolicyList }}
+        
+        <h3 id="security-{{ .TableName }}">Security: {{ .TableName }}</h3>
+        
+        <p>Row level security: {{ if .RLSEnabled }}enabled{{ if .RLSForced }} (forced for the table owner too){{ end }}{{ else }}disabled{{ end }}</p>
+        {{- if .GrantList }}
+        
+        <table class="styled-table">
+            <thead>
+                <tr>
+                    <th>Grantee</th>
+                    <th>Privilege</th>
+                    <th>Column</th>
+                    <th>Grantable</th>
+                </tr>
+            </thead>
+        {{- range .GrantList }}
+            <tbody>
+                <tr>
+                    <td style="text-align:left">{{ .Grantee }}</td>
+                    <td style="text-align:left">{{ .Privilege }}</td>
+                    <td style="text-align:left">{{ .Column }}</td>
+                    <td style="text-align:center">{{ if .Grantable }}&#x2714;{{ end }}</td>
+                </tr>
+            </tbody>
+        {{- end }}
+        </table>
+        {{- end }}
+        {{- if .PolicyList }}
+        
+        <table class="styled-table">
+            <thead>
+                <tr>
+                    <th>Policy</th>
+                    <th>Type</th>
+                    <th>Command</th>
+                    <th>Roles</th>
+                    <th>Using</th>
+                    <th>With check</th>
+                </tr>
+            </thead>
+        {{- range .PolicyList }}
+            <tbody>
+                <tr>
+                    <td style="text-align:left">{{ .Name }}</td>
+                    <td style="text-align:left">{{ .Permissive }}</td>
+                    <td style="text-align:left">{{ .Command }}</td>
+                    <td style="text-align:left">{{ range $i, $role := .Roles }}{{ if $i }}, {{ end }}{{ $role }}{{ end }}</td>
+                    <td style="text-align:left">{{ if .Using }}<code>{{ .Using }}</code>{{ end }}</td>
+                    <td style="text-align:left">{{ if .WithCheck }}<code>{{ .WithCheck }}</code>{{ end }}</td>
+                </tr>
+            </tbody>
+        {{- end }}
+        </table>
+        {{- end }}
+        {{- end }}
         
         <a href="#top">[Top &#x21a5;]</a>
+        
+        </div>
+        {{- end }}
+        {{- if .ViewList }}
+        
+        <h2 id="views">Views</h2>
+        
+        <table class="styled-table">
+            <thead>
+                <tr>
+                    <th>Name</th>
+                    <th>Kind</th>
+                    <th>Depends on</th>
+                    <th>Description</th>
+                </tr>
+            </thead>
+        {{- range .ViewList }}
+            <tbody>
+                <tr id="view-{{ .Name }}">
+                    <td style="text-align:left">{{ .Name }}</td>
+                    <td style="text-align:left">{{ .Kind }}</td>
+                    <td style="text-align:left">{{ range $i, $dep := .DependsOn }}{{ if $i }}<br>{{ end }}{{ if not $dep.Shown }}{{ $dep.Name }}{{ else if eq $dep.Kind "TABLE" }}<a href="#table-{{ $dep.Name }}">{{ $dep.Name }}</a>{{ else }}<a href="#view-{{ $dep.Name }}">{{ $dep.Name }}</a>{{ end }}{{ if $dep.Columns }} ({{ range $j, $column := $dep.Columns }}{{ if $j }}, {{ end }}{{ $column }}{{ end }}){{ end }}{{ end }}</td>
+                    <td style="text-align:left">{{ .Comment }}</td>
+                </tr>
+            </tbody>
         {{- end }}
+        </table>
+        
+        <a href="#top">[Top &#x21a5;]</a>
+        {{- end }}
+        {{- if .DomainList }}
+        
+        <h2 id="domains">Domains</h2>
+        
+        <table class="styled-table">
+            <thead>
+                <tr>
+                    <th>Name</th>
+                    <th>Base Type</th>
+                    <th>Not null</th>
+                    <th>Default Value</th>
+                    <th>Checks</th>
+                    <th>Description</th>
+                </tr>
+            </thead>
+        {{- range .DomainList }}
+            <tbody>
+                <tr id="domain-{{ .Name }}">
+                    <td style="text-align:left">{{ .Name }}</td>
+                    <td style="text-align:left">{{ .BaseType }}</td>
+                    <td style="text-align:center">{{ if .NotNull }}&#x2714;{{ end }}</td>
+                    <td style="text-align:left">{{ .DefaultValue }}</td>
+                    <td style="text-align:left">{{ range $i, $check := .CheckList }}{{ if $i }}<br>{{ end }}<code>{{ $check.Definition }}</code>{{ end }}</td>
+                    <td style="text-align:left">{{ .Comment }}</td>
+                </tr>
+            </tbody>
+        {{- end }}
+        </table>
+        
+        <a href="#top">[Top &#x21a5;]</a>
+        {{- end }}
+        {{- if .CustomTypeList }}
+        
+        <h2 id="types">Types</h2>
+        {{- range .CustomTypeList }}
+        
+        <h3 id="type-{{ .Name }}">Type: {{ .Name }}</h3>
+        {{- if .Comment }}
+        <p>{{ .Comment }}</p>
+        {{- end }}
+        
+        <table class="styled-table">
+            <thead>
+                <tr>
+                    <th>#</th>
+                    <th>Name</th>
+                    <th>Data Type</th>
+                </tr>
+            </thead>
+        {{- range .AttributeList }}
+            <tbody>
+                <tr>
+                    <td style="text-align:center">{{ .Ordinal }}</td>
+                    <td style="text-align:left">{{ .Name }}</td>
+                    <td style="text-align:left">{{ .DataType }}</td>
+                </tr>
+            </tbody>
+        {{- end }}
+        </table>
+        {{- end }}
+        
+        <a href="#top">[Top &#x21a5;]</a>
+        {{- end }}
+        {{- if .RoutineList }}
+        
+        <h2 id="routines">Routines</h2>
+        
+        <table class="styled-table">
+            <thead>
+                <tr>
+                    <th>Name</th>
+                    <th>Kind</th>
+                    <th>Returns</th>
+                    <th>Language</th>
+                    <th>Volatility</th>
+                    <th>Security definer</th>
+                    <th>Description</th>
+                </tr>
+            </thead>
+        {{- range .RoutineList }}
+            <tbody>
+                <tr id="{{ .ID }}">
+                    <td style="text-align:left"><code>{{ .Signature }}</code></td>
+                    <td style="text-align:left">{{ .Kind }}</td>
+                    <td style="text-align:left">{{ .ReturnType }}</td>
+                    <td style="text-align:left">{{ .Language }}</td>
+                    <td style="text-align:left">{{ .Volatility }}</td>
+                    <td style="text-align:center">{{ if .SecurityDefiner }}&#x2714;{{ end }}</td>
+                    <td style="text-align:left">{{ .Comment }}{{ if .Body }}<details><summary>Body</summary><pre><code>{{ .Body }}</code></pre></details>{{ end }}</td>
+                </tr>
+            </tbody>
+        {{- end }}
+        </table>
+        
+        <a href="#top">[Top &#x21a5;]</a>
+        {{- end }}
+        {{- if .SequenceList }}
+        
+        <h2 id="sequences">Sequences</h2>
+        
+        <table class="styled-table">
+            <thead>
+                <tr>
+                    <th>Name</th>
+                    <th>Data Type</th>
+                    <th>Start</th>
+                    <th>Increment</th>
+                    <th>Min</th>
+                    <th>Max</th>
+                    <th>Cycle</th>
+                    <th>Owned by</th>
+                </tr>
+            </thead>
+        {{- range .SequenceList }}
+            <tbody>
+                <tr id="sequence-{{ .Name }}">
+                    <td style="text-align:left">{{ .Name }}</td>
+                    <td style="text-align:left">{{ .DataType }}</td>
+                    <td style="text-align:right">{{ .StartValue }}</td>
+                    <td style="text-align:right">{{ .IncrementBy }}</td>
+                    <td style="text-align:right">{{ .MinValue }}</td>
+                    <td style="text-align:right">{{ .MaxValue }}</td>
+                    <td style="text-align:center">{{ if .Cycle }}&#x2714;{{ end }}</td>
+                    <td style="text-align:left">{{ if .OwnedByTable }}<a href="#table-{{ .OwnedByTable }}">{{ .OwnedByTable }}.{{ .OwnedByColumn }}</a>{{ end }}</td>
+                </tr>
+            </tbody>
+        {{- end }}
+        </table>
+        
+        <a href="#top">[Top &#x21a5;]</a>
+        {{- end }}
+        
+        <script>
+            (function () {
+                var search = document.getElementById("search");
+                var filters = {
+                    pk: document.getElementById("filter-pk"),
+                    fk: document.getElementById("filter-fk"),
+                    nullable: document.getElementById("filter-nullable")
+                };
+
+                function matchesFilters(row) {
+                    var active = Object.keys(filters).filter(function (key) { return filters[key].checked; });
+                    if (active.length === 0) {
+                        return true;
+                    }
+
+                    return active.some(function (key) { return row.getAttribute("data-" + key) === "true"; });
+                }
+
+                function apply() {
+                    var query = search.value.trim().toLowerCase();
+                    var filtering = query !== "" || Object.keys(filters).some(function (key) { return filters[key].checked; });
+                    var visibleTables = 0;
+                    var sections = document.querySelectorAll(".table-section");
+
+                    sections.forEach(function (section) {
+                        var tableMatches = query === "" || section.getAttribute("data-search").toLowerCase().indexOf(query) !== -1;
+                        var visibleColumns = 0;
+
+                        section.querySelectorAll(".column-row").forEach(function (row) {
+                            var columnMatches = tableMatches || row.getAttribute("data-search").toLowerCase().indexOf(query) !== -1;
+                            var visible = columnMatches && matchesFilters(row);
+                            row.style.display = visible ? "" : "none";
+                            if (visible) {
+                                visibleColumns++;
+                            }
+                        });
+
+                        var visibleTable = !filtering || visibleColumns > 0;
+                        section.style.display = visibleTable ? "" : "none";
+                        document.querySelectorAll(".toc-table").forEach(function (item) {
+                            if (item.getAttribute("data-table") === section.getAttribute("data-table")) {
+                                item.style.display = visibleTable ? "" : "none";
+                            }
+                        });
+                        if (visibleTable) {
+                            visibleTables++;
+                        }
+                    });
+
+                    document.getElementById("search-count").textContent = filtering ? visibleTables + " of " + sections.length + " tables" : "";
+                }
+
+                search.addEventListener("input", apply);
+                Object.keys(filters).forEach(function (key) {
+                    filters[key].addEventListener("change", apply);
+                });
+            })();
+        </script>
+        
+        <script>` + diagramPanZoomScript + `</script>
+    </body>
+</html>
+`
+)
+
+var (
+    siteTemplateStyle = `body {
+    margin: 0;
+    font-family: sans-serif;
+    color: #222222;
+}
+
+header {
+    padding: 12px 24px;
+    background-color: #009879;
+    color: #ffffff;
+}
+
+header a {
+    color: #ffffff;
+    margin-right: 16px;
+}
+
+main {
+    padding: 12px 24px;
+}
+
+.styled-table {
+    border-collapse: collapse;
+    margin: 25px 0;
+    font-size: 0.9em;
+    min-width: 400px;
+    box-shadow: 0 0 20px rgba(0, 0, 0, 0.15);
+}
+
+.styled-table thead tr {
+    background-color: #009879;
+    color: #ffffff;
+    text-align: left;
+}
+
+.styled-table th,
+.styled-table td {
+    padding: 12px 15px;
+    text-align: left;
+}
+
+.styled-table tbody tr {
+    border-bottom: 1px solid #dddddd;
+}
+
+.styled-table tbody tr:nth-of-type(even) {
+    background-color: #f3f3f3;
+}
+
+.styled-table tbody tr:last-of-type {
+    border-bottom: 2px solid #009879;
+}
+
+.badge {
+    display: inline-block;
+    padding: 1px 6px;
+    margin-left: 4px;
+    border-radius: 8px;
+    font-size: 0.75em;
+    color: #ffffff;
+    background-color: #6c757d;
+}
+
+.badge-pii {
+    background-color: #c0392b;
+}
+
+.badge-sensitive {
+    background-color: #d68910;
+}
+
+.search {
+    width: 400px;
+    padding: 8px;
+    font-size: 1em;
+}
+
+.diagram-container {
+    overflow: auto;
+    border: 1px solid #dddddd;
+}
+`
+
+    siteTemplateIndex = `<!DOCTYPE html>
+<html>
+    <head>
+        <meta charset="utf-8">
+        <title>Database: {{ .DatabaseName }}</title>
+        <link rel="stylesheet" href="style.css">
+    </head>
+    <body>
+        <header>
+            <a href="index.html">Data Directory: {{ .DatabaseName }}</a>
+            <a href="{{ schemaPage }}">Schema: {{ .SchemaName }}</a>
+        </header>
+        <main>
+            <h1>Tables</h1>
+            
+            <input id="search" class="search" type="search" placeholder="Search tables, columns and comments...">
+            
+            <table id="tables" class="styled-table">
+                <thead>
+                    <tr>
+                        <th>Table</th>
+                        {{- if .SubjectAreaList }}
+                        <th>Subject area</th>
+                        {{- end }}
+                        <th>Columns</th>
+                        <th>Description</th>
+                    </tr>
+                </thead>
+                <tbody>
+                {{- range .TableList }}
+                    <tr data-search="{{ .TableName }} {{ .SubjectArea }} {{ .Comment }}{{ range .Tags }} {{ . }}{{ end }}{{ range .ColumnList }} {{ .Name }} {{ .Comment }}{{ end }}">
+                        <td><a href="tables/{{ tablePage .TableName }}">{{ .TableName }}</a>{{ range .Tags }}<span class="badge">{{ . }}</span>{{ end }}</td>
+                        {{- if $.SubjectAreaList }}
+                        <td>{{ .SubjectArea }}</td>
+                        {{- end }}
+                        <td>{{ len .ColumnList }}</td>
+                        <td>{{ .Comment }}</td>
+                    </tr>
+                {{- end }}
+                </tbody>
+            </table>
+        </main>
+        <script>
+            document.getElementById("search").addEventListener("input", function (event) {
+                var query = event.target.value.trim().toLowerCase();
+                document.querySelectorAll("#tables tbody tr").forEach(function (row) {
+                    var text = row.getAttribute("data-search").toLowerCase();
+                    row.style.display = text.indexOf(query) === -1 ? "none" : "";
+                });
+            });
+        </script>
+    </body>
+</html>
+`
+
+    siteTemplateSchema = `<!DOCTYPE html>
+<html>
+    <head>
+        <meta charset="utf-8">
+        <title>Schema: {{ .SchemaName }}</title>
+        <link rel="stylesheet" href="style.css">
+    </head>
+    <body>
+        <header>
+            <a href="index.html">Data Directory: {{ .DatabaseName }}</a>
+            <a href="{{ schemaPage }}">Schema: {{ .SchemaName }}</a>
+        </header>
+        <main>
+            <h1>Schema: {{ .SchemaName }}</h1>
+            
+            <h2>Diagram</h2>
+            
+            <div class="diagram-container">{{ .Diagram }}</div>
+            {{- if .SubjectAreaList }}
+            
+            <h2>Subject Areas</h2>
+            {{- range .SubjectAreaList }}
+            
+            <h3>{{ .Name }}</h3>
+            {{- if .Description }}
+            <p>{{ .Description }}</p>
+            {{- end }}
+            <ul>
+            {{- range .TableList }}
+                <li><a href="tables/{{ tablePage .TableName }}">{{ .TableName }}</a></li>
+            {{- end }}
+            </ul>
+            {{- end }}
+            {{- end }}
+            
+            <h2>Tables</h2>
+            
+            <table class="styled-table">
+                <thead>
+                    <tr>
+                        <th>Table</th>
+                        <th>Columns</th>
+                        <th>Description</th>
+                    </tr>
+                </thead>
+                <tbody>
+                {{- range .TableList }}
+                    <tr>
+                        <td><a href="tables/{{ tablePage .TableName }}">{{ .TableName }}</a></td>
+                        <td>{{ len .ColumnList }}</td>
+                        <td>{{ .Comment }}</td>
+                    </tr>
+                {{- end }}
+                </tbody>
+            </table>
+            {{- if .ViewList }}
+            
+            <h2>Views</h2>
+            
+            <table class="styled-table">
+                <thead>
+                    <tr>
+                        <th>Name</th>
+                        <th>Kind</th>
+                        <th>Depends on</th>
+                        <th>Description</th>
+                    </tr>
+                </thead>
+                <tbody>
+                {{- range .ViewList }}
+                    <tr id="view-{{ .Name }}">
+                        <td>{{ .Name }}</td>
+                        <td>{{ .Kind }}</td>
+                        <td>{{ range $i, $dep := .DependsOn }}{{ if $i }}<br>{{ end }}{{ if not $dep.Shown }}{{ $dep.Name }}{{ else if eq $dep.Kind "TABLE" }}<a href="tables/{{ tablePage $dep.Name }}">{{ $dep.Name }}</a>{{ else }}<a href="#view-{{ $dep.Name }}">{{ $dep.Name }}</a>{{ end }}{{ if $dep.Columns }} ({{ range $j, $column := $dep.Columns }}{{ if $j }}, {{ end }}{{ $column }}{{ end }}){{ end }}{{ end }}</td>
+                        <td>{{ .Comment }}</td>
+                    </tr>
+                {{- end }}
+                </tbody>
+            </table>
+            {{- end }}
+            {{- if .DomainList }}
+            
+            <h2>Domains</h2>
+            
+            <table class="styled-table">
+                <thead>
+                    <tr>
+                        <th>Name</th>
+                        <th>Base Type</th>
+                        <th>Not null</th>
+                        <th>Default Value</th>
+                        <th>Checks</th>
+                        <th>Description</th>
+                    </tr>
+                </thead>
+                <tbody>
+                {{- range .DomainList }}
+                    <tr id="domain-{{ .Name }}">
+                        <td>{{ .Name }}</td>
+                        <td>{{ .BaseType }}</td>
+                        <td>{{ if .NotNull }}&#x2714;{{ end }}</td>
+                        <td>{{ .DefaultValue }}</td>
+                        <td>{{ range $i, $check := .CheckList }}{{ if $i }}<br>{{ end }}<code>{{ $check.Definition }}</code>{{ end }}</td>
+                        <td>{{ .Comment }}</td>
+                    </tr>
+                {{- end }}
+                </tbody>
+            </table>
+            {{- end }}
+            {{- if .CustomTypeList }}
+            
+            <h2>Types</h2>
+            {{- range .CustomTypeList }}
+            
+            <h3 id="type-{{ .Name }}">Type: {{ .Name }}</h3>
+            {{- if .Comment }}
+            <p>{{ .Comment }}</p>
+            {{- end }}
+            
+            <table class="styled-table">
+                <thead>
+                    <tr>
+                        <th>#</th>
+                        <th>Name</th>
+                        <th>Data Type</th>
+                    </tr>
+                </thead>
+                <tbody>
+                {{- range .AttributeList }}
+                    <tr>
+                        <td>{{ .Ordinal }}</td>
+                        <td>{{ .Name }}</td>
+                        <td>{{ .DataType }}</td>
+                    </tr>
+                {{- end }}
+                </tbody>
+            </table>
+            {{- end }}
+            {{- end }}
+            {{- if .RoutineList }}
+            
+            <h2>Routines</h2>
+            
+            <table class="styled-table">
+                <thead>
+                    <tr>
+                        <th>Name</th>
+                        <th>Kind</th>
+                        <th>Returns</th>
+                        <th>Language</th>
+                        <th>Volatility</th>
+                        <th>Security definer</th>
+                        <th>Description</th>
+                    </tr>
+                </thead>
+                <tbody>
+                {{- range .RoutineList }}
+                    <tr id="{{ .ID }}">
+                        <td><code>{{ .Signature }}</code></td>
+                        <td>{{ .Kind }}</td>
+                        <td>{{ .ReturnType }}</td>
+                        <td>{{ .Language }}</td>
+                        <td>{{ .Volatility }}</td>
+                        <td>{{ if .SecurityDefiner }}&#x2714;{{ end }}</td>
+                        <td>{{ .Comment }}{{ if .Body }}<details><summary>Body</summary><pre><code>{{ .Body }}</code></pre></details>{{ end }}</td>
+                    </tr>
+                {{- end }}
+                </tbody>
+            </table>
+            {{- end }}
+            {{- if .SequenceList }}
+            
+            <h2>Sequences</h2>
+            
+            <table class="styled-table">
+                <thead>
+                    <tr>
+                        <th>Name</th>
+                        <th>Data Type</th>
+                        <th>Start</th>
+                        <th>Increment</th>
+                        <th>Min</th>
+                        <th>Max</th>
+                        <th>Cycle</th>
+                        <th>Owned by</th>
+                    </tr>
+                </thead>
+                <tbody>
+                {{- range .SequenceList }}
+                    <tr id="sequence-{{ .Name }}">
+                        <td>{{ .Name }}</td>
+                        <td>{{ .DataType }}</td>
+                        <td>{{ .StartValue }}</td>
+                        <td>{{ .IncrementBy }}</td>
+                        <td>{{ .MinValue }}</td>
+                        <td>{{ .MaxValue }}</td>
+                        <td>{{ if .Cycle }}&#x2714;{{ end }}</td>
+                        <td>{{ if .OwnedByTable }}<a href="tables/{{ tablePage .OwnedByTable }}">{{ .OwnedByTable }}.{{ .OwnedByColumn }}</a>{{ end }}</td>
+                    </tr>
+                {{- end }}
+                </tbody>
+            </table>
+            {{- end }}
+        </main>
+    </body>
+</html>
+`
+
+    siteTemplateTable = `<!DOCTYPE html>
+<html>
+    <head>
+        <meta charset="utf-8">
+        <title>Table: {{ .Table.TableName }}</title>
+        <link rel="stylesheet" href="../style.css">
+    </head>
+    <body>
+        <header>
+            <a href="../index.html">Data Directory: {{ .DatabaseName }}</a>
+            <a href="../{{ schemaPage }}">Schema: {{ .SchemaName }}</a>
+        </header>
+        <main>
+        {{- with .Table }}
+            <h1>Table: {{ .TableName }}{{ range .Tags }}<span class="badge">{{ . }}</span>{{ end }}</h1>
+            {{- if .SubjectArea }}
+            
+            <p>Subject area: {{ .SubjectArea }}</p>
+            {{- end }}
+            {{- if .UsedByViews }}
+            
+            <p>Used by views: {{ range $i, $view := .UsedByViews }}{{ if $i }}, {{ end }}<a href="../{{ schemaPage }}#view-{{ $view }}">{{ $view }}</a>{{ end }}</p>
+            {{- end }}
+            {{- if .InheritsFrom }}
+            
+            <p>Inherits from: {{ range $i, $parent := .InheritsFrom }}{{ if $i }}, {{ end }}<a href="{{ tablePage $parent }}">{{ $parent }}</a>{{ end }}</p>
+            {{- end }}
+            {{- if .InheritedBy }}
+            
+            <p>Inherited by: {{ range $i, $child := .InheritedBy }}{{ if $i }}, {{ end }}<a href="{{ tablePage $child }}">{{ $child }}</a>{{ end }}</p>
+            {{- end }}
+            {{- with .Partitioning }}
+            {{- if .PartitionOf }}
+            
+            <p>Partition of: {{ if .PartitionOfShown }}<a href="{{ tablePage .PartitionOf }}">{{ .PartitionOf }}</a>{{ else }}{{ .PartitionOf }}{{ end }} <code>{{ .Bound }}</code></p>
+            {{- end }}
+            {{- if .Strategy }}
+            
+            <p>Partitioned by: {{ .Strategy }} <code>{{ .Key }}</code></p>
+            {{- end }}
+            {{- end }}
+            {{- if .PartitionList }}
+            
+            <table class="styled-table">
+                <thead>
+                    <tr>
+                        <th>Partition</th>
+                        <th>Bound</th>
+                    </tr>
+                </thead>
+                <tbody>
+                {{- range .PartitionList }}
+                    <tr>
+                        <td>{{ if .Shown }}<a href="{{ tablePage .Name }}">{{ .Name }}</a>{{ else }}{{ .Name }}{{ end }}</td>
+                        <td><code>{{ .Bound }}</code></td>
+                    </tr>
+                {{- end }}
+                </tbody>
+            </table>
+            {{- end }}
+            {{- if .Comment }}
+            
+            <p>{{ .Comment }}</p>
+            {{- end }}
+            {{- if .EstimatedRows }}
+            
+            <p>Estimated rows: {{ .EstimatedRows }}</p>
+            {{- end }}
+            
+            <h2>Columns</h2>
+            
+            <table class="styled-table">
+                <thead>
+                    <tr>
+                        <th>#</th>
+                        <th>Name</th>
+                        <th>Data Type</th>
+                        <th>PK</th>
+                        <th>FK</th>
+                        <th>UQ</th>
+                        <th>Not null</th>
+                        <th>Default Value</th>
+                        <th>Description</th>
+                        {{- if $.Sampled }}
+                        <th>Examples</th>
+                        {{- end }}
+                    </tr>
+                </thead>
+                <tbody>
+                {{- range .ColumnList }}
+                    <tr>
+                        <td>{{ .Ordinal }}</td>
+                        <td>{{ .Name }}{{ range .Tags }}<span class="badge{{ if eq . "pii" }} badge-pii{{ else if eq . "sensitive" }} badge-sensitive{{ end }}">{{ . }}</span>{{ end }}</td>
+                        <td>{{ if .Domain }}<a href="../{{ schemaPage }}#domain-{{ .Domain }}">{{ .DataType }}</a>{{ else if .CustomType }}<a href="../{{ schemaPage }}#type-{{ .CustomType }}">{{ .DataType }}</a>{{ else }}{{ .DataType }}{{ end }}</td>
+                        <td>{{ if .PK }}&#x2714;{{ end }}</td>
+                        <td>{{ if .FK }}&#x2714;{{ end }}</td>
+                        <td>{{ if .UQ }}&#x2714;{{ end }}</td>
+                        <td>{{ if .NotNull }}&#x2714;{{ end }}</td>
+                        <td>{{ if .Identity }}<span class="badge">identity</span> {{ .Identity }}{{ else if .Generated }}<span class="badge">generated</span> <code>{{ .Generated }}</code>{{ else }}{{ .DefaultValue }}{{ end }}{{ if .Sequence }} (<a href="../{{ schemaPage }}#sequence-{{ .Sequence }}">{{ .Sequence }}</a>){{ end }}</td>
+                        <td>{{ .Comment }}</td>
+                        {{- if $.Sampled }}
+                        <td>{{ if .ExamplesRedacted }}<i>redacted</i>{{ else if .ExamplesUnavailable }}<i>unavailable</i>{{ else }}{{ range $i, $example := .Examples }}{{ if $i }}, {{ end }}<code>{{ $example }}</code>{{ end }}{{ end }}</td>
+                        {{- end }}
+                    </tr>
+                {{- end }}
+                </tbody>
+            </table>
+            
+            <h2>Constraints</h2>
+            
+            <table class="styled-table">
+                <thead>
+                    <tr>
+                        <th>Name</th>
+                        <th>Type</th>
+                        <th>Column(s)</th>
+                        <th>References</th>
+                    </tr>
+                </thead>
+                <tbody>
+                {{- range .ConstraintsList }}
+                    <tr>
+                        <td>{{ .Name }}</td>
+                        <td>{{ .Type }}{{ if .Inferred }} <i>(inferred)</i>{{ end }}</td>
+                        <td>{{ .Column }}</td>
+                        <td>{{ if .ReferencesTable }}<a href="{{ tablePage .ReferencesTable }}">{{ .ReferencesTable }}.{{ .ReferencesColumn }}</a>{{ end }}</td>
+                    </tr>
+                {{- end }}
+                </tbody>
+            </table>
+            
+            <h2>Indexes</h2>
+            
+            <table class="styled-table">
+                <thead>
+                    <tr>
+                        <th>Name</th>
+                        <th>Column(s)</th>
+                        <th>Unique</th>
+                        <th>Primary</th>
+                        <th>Definition</th>
+                    </tr>
+                </thead>
+                <tbody>
+                {{- range .IndexList }}
+                    <tr>
+                        <td>{{ .Name }}</td>
+                        <td>{{ range $i, $column := .Columns }}{{ if $i }}, {{ end }}{{ $column }}{{ end }}</td>
+                        <td>{{ if .Unique }}&#x2714;{{ end }}</td>
+                        <td>{{ if .Primary }}&#x2714;{{ end }}</td>
+                        <td><code>{{ .Definition }}</code></td>
+                    </tr>
+                {{- end }}
+                </tbody>
+            </table>
+            {{- if .TriggerList }}
+            
+            <h2>Triggers</h2>
+            
+            <table class="styled-table">
+                <thead>
+                    <tr>
+                        <th>Name</th>
+                        <th>Timing</th>
+                        <th>Events</th>
+                        <th>Level</th>
+                        <th>Enabled</th>
+                        <th>Function</th>
+                        <th>When</th>
+                        <th>Definition</th>
+                    </tr>
+                </thead>
+                <tbody>
+                {{- range .TriggerList }}
+                    <tr>
+                        <td>{{ .Name }}</td>
+                        <td>{{ .Timing }}</td>
+                        <td>{{ range $i, $event := .Events }}{{ if $i }}, {{ end }}{{ $event }}{{ end }}</td>
+                        <td>{{ .Level }}</td>
+                        <td>{{ .Enabled }}</td>
+                        <td>{{ if .RoutineID }}<a href="../{{ schemaPage }}#{{ .RoutineID }}">{{ .Function }}</a>{{ else }}{{ .Function }}{{ end }}</td>
+                        <td>{{ if .When }}<code>{{ .When }}</code>{{ end }}</td>
+                        <td><code>{{ .Definition }}</code></td>
+                    </tr>
+                {{- end }}
+                </tbody>
+            </table>
+            {{- end }}
+            {{- if or .RLSEnabled .GrantList .PolicyList }}
+            
+            <h2>Security</h2>
+            
+            <p>Row level security: {{ if .RLSEnabled }}enabled{{ if .RLSForced }} (forced for the table owner too){{ end }}{{ else }}disabled{{ end }}</p>
+            {{- if .GrantList }}
+            
+            <table class="styled-table">
+                <thead>
+                    <tr>
+                        <th>Grantee</th>
+                        <th>Privilege</th>
+                        <th>Column</th>
+                        <th>Grantable</th>
+                    </tr>
+                </thead>
+                <tbody>
+                {{- range .GrantList }}
+                    <tr>
+                        <td>{{ .Grantee }}</td>
+                        <td>{{ .Privilege }}</td>
+                        <td>{{ .Column }}</td>
+                        <td>{{ if .Grantable }}&#x2714;{{ end }}</td>
+                    </tr>
+                {{- end }}
+                </tbody>
+            </table>
+            {{- end }}
+            {{- if .PolicyList }}
+            
+            <table class="styled-table">
+                <thead>
+                    <tr>
+                        <th>Policy</th>
+                        <th>Type</th>
+                        <th>Command</th>
+                        <th>Roles</th>
+                        <th>Using</th>
+                        <th>With check</th>
+                    </tr>
+                </thead>
+                <tbody>
+                {{- range .PolicyList }}
+                    <tr>
+                        <td>{{ .Name }}</td>
+                        <td>{{ .Permissive }}</td>
+                        <td>{{ .Command }}</td>
+                        <td>{{ range $i, $role := .Roles }}{{ if $i }}, {{ end }}{{ $role }}{{ end }}</td>
+                        <td>{{ if .Using }}<code>{{ .Using }}</code>{{ end }}</td>
+                        <td>{{ if .WithCheck }}<code>{{ .WithCheck }}</code>{{ end }}</td>
+                    </tr>
+                {{- end }}
+                </tbody>
+            </table>
+            {{- end }}
+            {{- end }}
+        {{- end }}
+            
+            <h2>Relationships</h2>
+            
+            <table class="styled-table">
+                <thead>
+                    <tr>
+                        <th>Direction</th>
+                        <th>Name</th>
+                        <th>Column(s)</th>
+                        <th>Table</th>
+                        <th>Column(s)</th>
+                    </tr>
+                </thead>
+                <tbody>
+                {{- range .Outgoing }}
+                    <tr>
+                        <td>Outgoing</td>
+                        <td>{{ .Name }}{{ if .Inferred }} <i>(inferred)</i>{{ end }}</td>
+                        <td>{{ .Columns }}</td>
+                        <td><a href="{{ tablePage .ReferencesTable }}">{{ .ReferencesTable }}</a></td>
+                        <td>{{ .ReferencesColumns }}</td>
+                    </tr>
+                {{- end }}
+                {{- range .Incoming }}
+                    <tr>
+                        <td>Incoming</td>
+                        <td>{{ .Name }}{{ if .Inferred }} <i>(inferred)</i>{{ end }}</td>
+                        <td>{{ .ReferencesColumns }}</td>
+                        <td><a href="{{ tablePage .Table }}">{{ .Table }}</a></td>
+                        <td>{{ .Columns }}</td>
+                    </tr>
+                {{- end }}
+                </tbody>
+            </table>
+            
+            <h2>Diagram</h2>
+            
+            <div class="diagram-container">{{ .Diagram }}</div>
+        </main>
     </body>
 </html>
 `
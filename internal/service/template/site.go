@@ -0,0 +1,215 @@
+package template
+
+import (
+    "bytes"
+    "fmt"
+    "html/template"
+    "regexp"
+
+    "github.com/eujoy/data-dict/internal/model/domain"
+    "github.com/eujoy/data-dict/pkg"
+)
+
+const (
+    siteIndexPage  = "index.html"
+    siteStylePage  = "style.css"
+    siteTablesDir  = "tables"
+    siteSchemaPage = "schema-%v.html"
+)
+
+var unsafePageNameRegex = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// siteRelationship describes an incoming or outgoing (possibly multi column) relationship of a table in the site.
+type siteRelationship struct {
+    Name              string
+    Table             string
+    Columns           string
+    ReferencesTable   string
+    ReferencesColumns string
+    Inferred          bool
+}
+
+// siteSchemaValues describes the values of the schema page of the site.
+type siteSchemaValues struct {
+    domain.TemplateValues
+    Diagram template.HTML
+}
+
+// siteTableValues describes the values of a table page of the site.
+type siteTableValues struct {
+    domain.TemplateValues
+    Table    domain.TableTmplValue
+    Outgoing []siteRelationship
+    Incoming []siteRelationship
+    Diagram  template.HTML
+}
+
+// GenerateSite generates the pages of the static documentation site, keyed by their path relative to the site root.
+func (eng *Engine) GenerateSite(templateValues domain.TemplateValues) (map[string]string, *pkg.Error) {
+    err := normalizeDiagramDetail(&templateValues)
+    if err != nil {
+        return nil, err
+    }
+
+    funcMap := template.FuncMap{
+        "tablePage":  tablePageName,
+        "schemaPage": func() string { return schemaPageName(templateValues.SchemaName) },
+    }
+
+    pages := map[string]string{
+        siteStylePage: siteTemplateStyle,
+    }
+
+    pages[siteIndexPage], err = eng.generateSitePage(siteTemplateIndex, funcMap, templateValues)
+    if err != nil {
+        return nil, err
+    }
+
+    schemaValues := siteSchemaValues{
+        TemplateValues: templateValues,
+        Diagram: template.HTML(renderDiagramSVG(templateValues.TableList, templateValues.DiagramDetail, func(tableName string) string {
+            return siteTablesDir + "/" + tablePageName(tableName)
+        }, nil, templateValues.ViewList)),
+    }
+
+    pages[schemaPageName(templateValues.SchemaName)], err = eng.generateSitePage(siteTemplateSchema, funcMap, schemaValues)
+    if err != nil {
+        return nil, err
+    }
+
+    for _, tb := range templateValues.TableList {
+        tableValues := siteTableValues{
+            TemplateValues: templateValues,
+            Table:          tb,
+            Outgoing:       outgoingRelationships(tb),
+            Incoming:       incomingRelationships(tb),
+        }
+
+        neighbors := map[string]bool{tb.TableName: true}
+        for _, rel := range tableValues.Outgoing {
+            neighbors[rel.ReferencesTable] = true
+        }
+        for _, rel := range tableValues.Incoming {
+            neighbors[rel.Table] = true
+        }
+        for _, parent := range tb.InheritsFrom {
+            neighbors[parent] = true
+        }
+        for _, child := range tb.InheritedBy {
+            neighbors[child] = true
+        }
+
+        var neighborList []domain.TableTmplValue
+        for _, neighbor := range templateValues.TableList {
+            if neighbors[neighbor.TableName] {
+                neighborList = append(neighborList, neighbor)
+            }
+        }
+
+        tableValues.Diagram = template.HTML(renderDiagramSVG(neighborList, templateValues.DiagramDetail, tablePageName, nil, nil))
+
+        pages[siteTablesDir+"/"+tablePageName(tb.TableName)], err = eng.generateSitePage(siteTemplateTable, funcMap, tableValues)
+        if err != nil {
+            return nil, err
+        }
+    }
+
+    return pages, nil
+}
+
+// generateSitePage prepares and generates a page of the site.
+func (eng *Engine) generateSitePage(pageTemplate string, funcMap template.FuncMap, values interface{}) (string, *pkg.Error) {
+    t, templateErr := template.New("site").Funcs(funcMap).Parse(pageTemplate)
+    if templateErr != nil {
+        err := &pkg.Error{Err: templateErr}
+        err.LogError()
+        return "", err
+    }
+
+    var tplData bytes.Buffer
+    tmplExecErr := t.Execute(&tplData, values)
+    if tmplExecErr != nil {
+        err := &pkg.Error{Err: tmplExecErr}
+        err.LogError()
+        return "", err
+    }
+
+    return tplData.String(), nil
+}
+
+// outgoingRelationships groups the (possibly multi column) foreign key constraints of the table to relationships.
+func outgoingRelationships(tb domain.TableTmplValue) []siteRelationship {
+    var relationships []siteRelationship
+    relPosition := make(map[string]int)
+    for _, constr := range tb.ConstraintsList {
+        if constr.ReferencesTable == "" {
+            continue
+        }
+
+        pos, ok := relPosition[constr.Name]
+        if !ok {
+            pos = len(relationships)
+            relPosition[constr.Name] = pos
+            relationships = append(relationships, siteRelationship{
+                Name:            constr.Name,
+                Table:           tb.TableName,
+                ReferencesTable: constr.ReferencesTable,
+                Inferred:        constr.Inferred,
+            })
+        }
+
+        relationships[pos].Columns = joinColumn(relationships[pos].Columns, constr.Column)
+        relationships[pos].ReferencesColumns = joinColumn(relationships[pos].ReferencesColumns, constr.ReferencesColumn)
+    }
+
+    return relationships
+}
+
+// incomingRelationships groups the incoming references of the table to relationships.
+func incomingRelationships(tb domain.TableTmplValue) []siteRelationship {
+    var relationships []siteRelationship
+    relPosition := make(map[string]int)
+    for _, ref := range tb.ReferencedBy {
+        relKey := ref.Table + "\x00" + ref.Name
+        pos, ok := relPosition[relKey]
+        if !ok {
+            pos = len(relationships)
+            relPosition[relKey] = pos
+            relationships = append(relationships, siteRelationship{
+                Name:            ref.Name,
+                Table:           ref.Table,
+                ReferencesTable: tb.TableName,
+                Inferred:        ref.Inferred,
+            })
+        }
+
+        relationships[pos].Columns = joinColumn(relationships[pos].Columns, ref.Column)
+        relationships[pos].ReferencesColumns = joinColumn(relationships[pos].ReferencesColumns, ref.ReferencedColumn)
+    }
+
+    return relationships
+}
+
+// joinColumn appends the column to the comma separated list of columns.
+func joinColumn(columns string, column string) string {
+    if columns == "" {
+        return column
+    }
+
+    return columns + ", " + column
+}
+
+// tablePageName returns the file name of the page of the table, replacing the characters that are not safe for file
+// names.
+func tablePageName(tableName string) string {
+    return unsafePageNameRegex.ReplaceAllString(tableName, "_") + ".html"
+}
+
+// schemaPageName returns the file name of the page of the schema.
+func schemaPageName(schemaName string) string {
+    if schemaName == "" {
+        schemaName = "default"
+    }
+
+    return fmt.Sprintf(siteSchemaPage, unsafePageNameRegex.ReplaceAllString(schemaName, "_"))
+}
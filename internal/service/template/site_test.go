@@ -0,0 +1,75 @@
+package template
+
+import (
+    "reflect"
+    "strings"
+    "testing"
+
+    "github.com/eujoy/data-dict/internal/model/domain"
+)
+
+// compositeTemplateValues returns an order_lines table with a composite foreign key to the orders table, in the shape of
+// the column pairs returned by the repository.
+func compositeTemplateValues() domain.TemplateValues {
+    return domain.TemplateValues{
+        SchemaName: "public",
+        TableList: []domain.TableTmplValue{
+            {
+                TableName: "order_lines",
+                ConstraintsList: []domain.ConstraintTmplValue{
+                    {Name: "order_lines_order_fkey", Type: "FOREIGN KEY", Column: "tenant_id", ReferencesTable: "orders", ReferencesColumn: "tenant_id"},
+                    {Name: "order_lines_order_fkey", Type: "FOREIGN KEY", Column: "order_id", ReferencesTable: "orders", ReferencesColumn: "id"},
+                },
+            },
+            {
+                TableName: "orders",
+                ReferencedBy: []domain.ReferenceTmplValue{
+                    {Name: "order_lines_order_fkey", Table: "order_lines", Column: "tenant_id", ReferencedColumn: "tenant_id"},
+                    {Name: "order_lines_order_fkey", Table: "order_lines", Column: "order_id", ReferencedColumn: "id"},
+                },
+            },
+        },
+    }
+}
+
+func TestSiteRelationships(t *testing.T) {
+    expected := []siteRelationship{
+        {Name: "order_lines_order_fkey", Table: "order_lines", Columns: "tenant_id, order_id", ReferencesTable: "orders", ReferencesColumns: "tenant_id, id"},
+    }
+
+    templateValues := compositeTemplateValues()
+    testCases := map[string]struct {
+        actual []siteRelationship
+    }{
+        "outgoing composite foreign key keeps the column pairs": {
+            actual: outgoingRelationships(templateValues.TableList[0]),
+        },
+        "incoming composite foreign key keeps the column pairs": {
+            actual: incomingRelationships(templateValues.TableList[1]),
+        },
+    }
+
+    for name, tc := range testCases {
+        t.Run(name, func(t *testing.T) {
+            if !reflect.DeepEqual(tc.actual, expected) {
+                t.Errorf("expected relationships %+v, got %+v", expected, tc.actual)
+            }
+        })
+    }
+}
+
+func TestGenerateSiteCompositeForeignKey(t *testing.T) {
+    pages, err := New().GenerateSite(compositeTemplateValues())
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err.Err)
+    }
+
+    for _, tableName := range []string{"order_lines", "orders"} {
+        page := pages[siteTablesDir+"/"+tablePageName(tableName)]
+        for _, columns := range []string{"<td>tenant_id, order_id</td>", "<td>tenant_id, id</td>"} {
+            if strings.Count(page, columns) != 1 {
+                t.Errorf("expected the page of '%v' to contain %v once, got:\n%v", tableName, columns, page)
+            }
+        }
+    }
+}
@@ -0,0 +1,296 @@
+package template
+
+import (
+    "fmt"
+    "html/template"
+    "math"
+    "strings"
+
+    "github.com/eujoy/data-dict/internal/model/domain"
+)
+
+const (
+    svgCharWidth    = 7
+    svgPadding      = 10
+    svgHeaderHeight = 24
+    svgRowHeight    = 18
+    svgGap          = 60
+    svgMinBoxWidth  = 120
+)
+
+// svgNode describes a box of the svg diagram along with the page (or anchor) it links to.
+type svgNode struct {
+    id     string
+    title  string
+    href   string
+    lines  []string
+    stub   bool
+    view   bool
+    x      int
+    y      int
+    width  int
+    height int
+}
+
+// svgEdge describes a line between two boxes of the svg diagram. Inheritance and lineage lines are drawn distinctly
+// from the relationship ones.
+type svgEdge struct {
+    from     string
+    to       string
+    title    string
+    label    string
+    dashed   bool
+    inherits bool
+    lineage  bool
+}
+
+// renderDiagramSVG draws the tables and their relationships as a self contained svg diagram. The columns drawn for
+// each table depend on the diagram detail and each table links to the page returned by the provided href function.
+// The stub tables (keyed by their name, along with the subject area they belong to) are drawn without any columns. The
+// views are drawn along with the lineage lines to the tables (or views) they depend on, each one linking to its anchor.
+func renderDiagramSVG(tableList []domain.TableTmplValue, diagramDetail string, href func(tableName string) string, stubAreas map[string]string, viewList []domain.ViewTmplValue) string {
+    var nodes []*svgNode
+    var edges []svgEdge
+    for _, tb := range tableList {
+        node := &svgNode{id: tb.TableName, title: tb.TableName, href: href(tb.TableName)}
+        if area, ok := stubAreas[tb.TableName]; ok {
+            node.stub = true
+            node.lines = []string{"(" + area + ")"}
+        } else if diagramDetail != diagramDetailNames {
+            for _, col := range tb.ColumnList {
+                if diagramDetail == diagramDetailKeys && !col.PK && !col.FK && !col.InferredFK && !col.UQ {
+                    continue
+                }
+
+                node.lines = append(node.lines, svgColumnLine(col))
+            }
+        }
+        nodes = append(nodes, node)
+
+        drawn := make(map[string]bool)
+        for _, constr := range tb.ConstraintsList {
+            if constr.ReferencesTable == "" || constr.ReferencesTable == tb.TableName || drawn[constr.Name] {
+                continue
+            }
+            drawn[constr.Name] = true
+
+            edges = append(edges, svgEdge{
+                from:   tb.TableName,
+                to:     constr.ReferencesTable,
+                title:  fmt.Sprintf("%v.%v to %v.%v", tb.TableName, constr.Column, constr.ReferencesTable, constr.ReferencesColumn),
+                dashed: constr.Inferred,
+            })
+        }
+
+        for _, parent := range tb.InheritsFrom {
+            edges = append(edges, svgEdge{
+                from:     tb.TableName,
+                to:       parent,
+                title:    fmt.Sprintf("%v inherits from %v", tb.TableName, parent),
+                inherits: true,
+            })
+        }
+    }
+
+    for _, vw := range viewList {
+        nodes = append(nodes, &svgNode{id: vw.Name, title: vw.Name, href: "#view-" + vw.Name, lines: []string{"(" + strings.ToLower(vw.Kind) + ")"}, view: true})
+
+        for _, dep := range vw.DependsOn {
+            edges = append(edges, svgEdge{
+                from:    vw.Name,
+                to:      dep.Name,
+                title:   fmt.Sprintf("%v depends on %v (%v)", vw.Name, dep.Name, strings.Join(dep.Columns, ", ")),
+                lineage: true,
+            })
+        }
+    }
+
+    return renderSVG(nodes, edges)
+}
+
+// renderAreaDiagramSVG draws the tables of a subject area, along with the stub tables of the other subject areas they
+// relate to, as a self contained svg diagram.
+func renderAreaDiagramSVG(subjectArea domain.SubjectAreaTmplValue, diagramDetail string, href func(tableName string) string) string {
+    tableList := append([]domain.TableTmplValue{}, subjectArea.TableList...)
+    stubAreas := make(map[string]string)
+    stubPosition := make(map[string]int)
+    for _, rel := range subjectArea.RelationshipList {
+        if rel.StubTable == "" {
+            continue
+        }
+
+        pos, ok := stubPosition[rel.StubTable]
+        if !ok {
+            pos = len(tableList)
+            stubPosition[rel.StubTable] = pos
+            stubAreas[rel.StubTable] = rel.StubArea
+            tableList = append(tableList, domain.TableTmplValue{TableName: rel.StubTable})
+        }
+
+        if rel.Table == rel.StubTable {
+            tableList[pos].ConstraintsList = append(tableList[pos].ConstraintsList, domain.ConstraintTmplValue{
+                Name:             rel.Table + "." + rel.Column,
+                Type:             "FOREIGN KEY",
+                Column:           rel.Column,
+                ReferencesTable:  rel.ReferencesTable,
+                ReferencesColumn: rel.ReferencesColumn,
+                Inferred:         rel.Inferred,
+            })
+        }
+    }
+
+    return renderDiagramSVG(tableList, diagramDetail, href, stubAreas, nil)
+}
+
+// renderAreaOverviewSVG draws the subject areas and the number of relationships among them as a self contained svg
+// diagram, with each subject area linking to its anchor.
+func renderAreaOverviewSVG(subjectAreaList []domain.SubjectAreaTmplValue, areaLinkList []domain.AreaLinkTmplValue) string {
+    var nodes []*svgNode
+    for _, area := range subjectAreaList {
+        nodes = append(nodes, &svgNode{
+            id:    area.ID,
+            title: area.Name,
+            href:  "#" + area.ID,
+            lines: []string{fmt.Sprintf("%d table(s)", len(area.TableList))},
+        })
+    }
+
+    var edges []svgEdge
+    for _, link := range areaLinkList {
+        edges = append(edges, svgEdge{
+            from:  link.FromID,
+            to:    link.ToID,
+            title: fmt.Sprintf("%d relationship(s) from %v to %v", link.Relationships, link.From, link.To),
+            label: fmt.Sprintf("%d", link.Relationships),
+        })
+    }
+
+    return renderSVG(nodes, edges)
+}
+
+// renderSVG places the boxes on a grid and draws them, along with the lines among them, as a self contained svg.
+func renderSVG(nodes []*svgNode, edges []svgEdge) string {
+    if len(nodes) == 0 {
+        return ""
+    }
+
+    nodePosition := make(map[string]int)
+    for i, node := range nodes {
+        longest := len(node.title)
+        for _, line := range node.lines {
+            if len(line) > longest {
+                longest = len(line)
+            }
+        }
+
+        node.width = int(math.Max(float64(longest*svgCharWidth+2*svgPadding), svgMinBoxWidth))
+        node.height = svgHeaderHeight + len(node.lines)*svgRowHeight + svgPadding/2
+        nodePosition[node.id] = i
+    }
+
+    columns := int(math.Ceil(math.Sqrt(float64(len(nodes)))))
+    columnWidths := make([]int, columns)
+    rowHeights := make([]int, (len(nodes)+columns-1)/columns)
+    for i, node := range nodes {
+        if node.width > columnWidths[i%columns] {
+            columnWidths[i%columns] = node.width
+        }
+        if node.height > rowHeights[i/columns] {
+            rowHeights[i/columns] = node.height
+        }
+    }
+
+    totalWidth, totalHeight := svgGap/2, svgGap/2
+    for _, w := range columnWidths {
+        totalWidth += w + svgGap
+    }
+    for _, h := range rowHeights {
+        totalHeight += h + svgGap
+    }
+
+    y := svgGap / 2
+    for row := range rowHeights {
+        x := svgGap / 2
+        for col := 0; col < columns && row*columns+col < len(nodes); col++ {
+            nodes[row*columns+col].x = x
+            nodes[row*columns+col].y = y
+            x += columnWidths[col] + svgGap
+        }
+        y += rowHeights[row] + svgGap
+    }
+
+    var sb strings.Builder
+    sb.WriteString(fmt.Sprintf(`<svg class="diagram" xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, totalWidth, totalHeight, totalWidth, totalHeight))
+    sb.WriteString(`<style>.diagram text{font-family:monospace;font-size:12px}.diagram .table-box{fill:#ffffff;stroke:#009879}.diagram .table-header{fill:#009879}.diagram .table-name{fill:#ffffff;font-weight:bold}.diagram .stub .table-box{stroke-dasharray:4 3}.diagram .stub .table-header{fill:#6c757d}.diagram .edge{stroke:#555555;stroke-width:1.5}.diagram .edge-inferred{stroke-dasharray:6 4}.diagram .edge-inheritance{stroke:#1f6feb;stroke-width:3;stroke-dasharray:2 3}.diagram .view .table-header{fill:#6f42c1}.diagram .view .table-box{stroke:#6f42c1}.diagram .edge-lineage{stroke:#6f42c1;stroke-dasharray:8 3}.diagram .edge-label{fill:#555555;font-weight:bold}</style>`)
+
+    for _, edge := range edges {
+        fromPos, fromOK := nodePosition[edge.from]
+        toPos, toOK := nodePosition[edge.to]
+        if !fromOK || !toOK {
+            continue
+        }
+
+        from, to := nodes[fromPos], nodes[toPos]
+        x1, y1 := from.x+from.width/2, from.y+from.height/2
+        x2, y2 := to.x+to.width/2, to.y+to.height/2
+
+        class := "edge"
+        if edge.dashed {
+            class += " edge-inferred"
+        }
+        if edge.inherits {
+            class += " edge-inheritance"
+        }
+        if edge.lineage {
+            class += " edge-lineage"
+        }
+
+        sb.WriteString(fmt.Sprintf(`<line class="%v" x1="%d" y1="%d" x2="%d" y2="%d"><title>%v</title></line>`, class, x1, y1, x2, y2, template.HTMLEscapeString(edge.title)))
+        if edge.label != "" {
+            sb.WriteString(fmt.Sprintf(`<text class="edge-label" x="%d" y="%d">%v</text>`, (x1+x2)/2+4, (y1+y2)/2-4, template.HTMLEscapeString(edge.label)))
+        }
+    }
+
+    for _, node := range nodes {
+        class := "table"
+        if node.stub {
+            class += " stub"
+        }
+        if node.view {
+            class += " view"
+        }
+
+        sb.WriteString(fmt.Sprintf(`<a href="%v"><g class="%v" id="diagram-%v">`, template.HTMLEscapeString(node.href), class, template.HTMLEscapeString(node.id)))
+        sb.WriteString(fmt.Sprintf(`<rect class="table-box" x="%d" y="%d" width="%d" height="%d"/>`, node.x, node.y, node.width, node.height))
+        sb.WriteString(fmt.Sprintf(`<rect class="table-header" x="%d" y="%d" width="%d" height="%d"/>`, node.x, node.y, node.width, svgHeaderHeight))
+        sb.WriteString(fmt.Sprintf(`<text class="table-name" x="%d" y="%d">%v</text>`, node.x+svgPadding, node.y+svgHeaderHeight-8, template.HTMLEscapeString(node.title)))
+        for i, line := range node.lines {
+            sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d">%v</text>`, node.x+svgPadding, node.y+svgHeaderHeight+(i+1)*svgRowHeight-5, template.HTMLEscapeString(line)))
+        }
+        sb.WriteString(`</g></a>`)
+    }
+
+    sb.WriteString(`</svg>`)
+    return sb.String()
+}
+
+// svgColumnLine formats a column as a line of its table in the svg diagram.
+func svgColumnLine(col domain.ColumnTmplValue) string {
+    var keys []string
+    if col.PK {
+        keys = append(keys, "PK")
+    }
+    if col.FK {
+        keys = append(keys, "FK")
+    }
+    if col.UQ {
+        keys = append(keys, "UQ")
+    }
+
+    line := fmt.Sprintf("%v: %v", col.Name, col.DataType)
+    if len(keys) > 0 {
+        line += " [" + strings.Join(keys, ",") + "]"
+    }
+
+    return line
+}
@@ -2,20 +2,64 @@ package template
 
 import (
     "bytes"
+    "encoding/csv"
+    "encoding/json"
     "fmt"
     "html/template"
+    "regexp"
+    "sort"
+    "strings"
 
     "github.com/eujoy/data-dict/internal/model/domain"
     "github.com/eujoy/data-dict/pkg"
 )
 
 const (
-    erDiagram = "er"
-    html      = "html"
-    markdown  = "md"
-    mermaid   = "mermaid"
+    accessMatrix = "access"
+    erDiagram    = "er"
+    html         = "html"
+    jsonSnapshot = "json"
+    markdown     = "md"
+    mermaid      = "mermaid"
+    register     = "register"
+    site         = "site"
 )
 
+const (
+    diagramDetailFull  = "full"
+    diagramDetailKeys  = "keys"
+    diagramDetailNames = "names"
+)
+
+// fileExtensions describes the extension of the output file for the output types that do not match it.
+var fileExtensions = map[string]string{
+    accessMatrix: "csv",
+    register:     "csv",
+}
+
+// templateFuncs describes the functions available to the single output templates.
+var templateFuncs = template.FuncMap{
+    "diagram": func(tableList []domain.TableTmplValue, viewList []domain.ViewTmplValue, diagramDetail string) template.HTML {
+        return template.HTML(renderDiagramSVG(tableList, diagramDetail, tableAnchor, nil, viewList))
+    },
+    "areaDiagram": func(subjectArea domain.SubjectAreaTmplValue, diagramDetail string) template.HTML {
+        return template.HTML(renderAreaDiagramSVG(subjectArea, diagramDetail, tableAnchor))
+    },
+    "areaOverviewDiagram": func(subjectAreaList []domain.SubjectAreaTmplValue, areaLinkList []domain.AreaLinkTmplValue) template.HTML {
+        return template.HTML(renderAreaOverviewSVG(subjectAreaList, areaLinkList))
+    },
+    "diagramType": diagramType,
+}
+
+// unsafeDiagramTypeRegex matches the characters that mermaid does not accept in the type of an attribute.
+var unsafeDiagramTypeRegex = regexp.MustCompile(`[^A-Za-z0-9_()\[\]]+`)
+
+// diagramType returns the data type of a column in a form that mermaid accepts as the type of an attribute, e.g.
+// "character varying(255)" becomes "character_varying(255)".
+func diagramType(dataType string) string {
+    return unsafeDiagramTypeRegex.ReplaceAllString(dataType, "_")
+}
+
 // Engine describes the template engine service.
 type Engine struct{}
 
@@ -26,23 +70,73 @@ func New() *Engine {
 
 // Generate and print the respective template.
 func (eng *Engine) Generate(outputType string, templateValues domain.TemplateValues) (string, *pkg.Error) {
+    err := normalizeDiagramDetail(&templateValues)
+    if err != nil {
+        return "", err
+    }
+
     switch outputType {
+    case accessMatrix:
+        return eng.generateAccessMatrix(templateValues)
     case erDiagram:
         return eng.generateType(dataDirectoryTemplateERDiagram, templateValues)
     case html:
         return eng.generateType(dataDirectoryTemplateHTML, templateValues)
+    case jsonSnapshot:
+        return eng.generateJSON(templateValues)
     case markdown:
         return eng.generateType(dataDirectoryTemplateMarkdown, templateValues)
     case mermaid:
         return eng.generateType(dataDirectoryTemplateMermaid, templateValues)
+    case register:
+        return eng.generateRegister(templateValues)
+    case site:
+        return "", &pkg.Error{Err: fmt.Errorf("output type '%v' generates a directory and cannot be generated as a single output", outputType)}
     default:
         return "", &pkg.Error{Err: fmt.Errorf("invalid output type provided: %v", outputType)}
     }
 }
 
+// FileExtension returns the extension (including the leading dot) of the file the output type should be written to.
+func (eng *Engine) FileExtension(outputType string) string {
+    if ext, ok := fileExtensions[outputType]; ok {
+        return "." + ext
+    }
+
+    return "." + outputType
+}
+
+// IsDirectoryOutput checks whether the output type generates a directory of files instead of a single output.
+func (eng *Engine) IsDirectoryOutput(outputType string) bool {
+    return outputType == site
+}
+
+// IsClassifiedOutput checks whether the output type requires the columns to be classified as pii or sensitive.
+func (eng *Engine) IsClassifiedOutput(outputType string) bool {
+    return outputType == register
+}
+
+// tableAnchor returns the anchor of the section of the table.
+func tableAnchor(tableName string) string {
+    return "#table-" + tableName
+}
+
+// normalizeDiagramDetail validates the diagram detail of the template values, defaulting to the full one.
+func normalizeDiagramDetail(templateValues *domain.TemplateValues) *pkg.Error {
+    switch templateValues.DiagramDetail {
+    case "":
+        templateValues.DiagramDetail = diagramDetailFull
+    case diagramDetailFull, diagramDetailKeys, diagramDetailNames:
+    default:
+        return &pkg.Error{Err: fmt.Errorf("invalid diagram detail provided: %v", templateValues.DiagramDetail)}
+    }
+
+    return nil
+}
+
 // generateType prepares, generates and print the respective template requested.
 func (eng *Engine) generateType(typeTemplate string, templateValues domain.TemplateValues) (string, *pkg.Error) {
-    t, templateErr := template.New("template").Parse(typeTemplate)
+    t, templateErr := template.New("template").Funcs(templateFuncs).Parse(typeTemplate)
     if templateErr != nil {
         err := &pkg.Error{Err: templateErr}
         err.LogError()
@@ -59,3 +153,127 @@ func (eng *Engine) generateType(typeTemplate string, templateValues domain.Templ
 
     return tplData.String(), nil
 }
+
+// generateJSON prepares the json snapshot of the template values.
+func (eng *Engine) generateJSON(templateValues domain.TemplateValues) (string, *pkg.Error) {
+    jsonData, marshalErr := json.MarshalIndent(templateValues, "", "  ")
+    if marshalErr != nil {
+        err := &pkg.Error{Err: marshalErr}
+        err.LogError()
+        return "", err
+    }
+
+    return string(jsonData) + "\n", nil
+}
+
+// generateAccessMatrix prepares the csv matrix of the privileges each role has been granted on each table. Each cell
+// lists the privileges separated by ';', with the columns in parentheses for the privileges granted only on some
+// columns and a '*' suffix for the privileges the role can grant to others.
+func (eng *Engine) generateAccessMatrix(templateValues domain.TemplateValues) (string, *pkg.Error) {
+    var csvData bytes.Buffer
+    w := csv.NewWriter(&csvData)
+
+    header := []string{"role"}
+    roleNames := make(map[string]bool)
+    cells := make(map[string]map[string][]string)
+    for _, tb := range templateValues.TableList {
+        rls := ""
+        if tb.RLSEnabled {
+            rls = " (rls)"
+        }
+        header = append(header, tb.TableName+rls)
+
+        columnPrivileges := make(map[string][]string)
+        var columnPrivilegeKeys []string
+        for _, grant := range tb.GrantList {
+            if !roleNames[grant.Grantee] {
+                roleNames[grant.Grantee] = true
+                cells[grant.Grantee] = make(map[string][]string)
+            }
+
+            privilege := grant.Privilege
+            if grant.Column != "" {
+                privilege = grant.Column
+            }
+            if grant.Grantable {
+                privilege += "*"
+            }
+
+            if grant.Column == "" {
+                cells[grant.Grantee][tb.TableName] = append(cells[grant.Grantee][tb.TableName], privilege)
+                continue
+            }
+
+            key := grant.Grantee + "\x00" + grant.Privilege
+            if _, ok := columnPrivileges[key]; !ok {
+                columnPrivilegeKeys = append(columnPrivilegeKeys, key)
+            }
+            columnPrivileges[key] = append(columnPrivileges[key], privilege)
+        }
+
+        for _, key := range columnPrivilegeKeys {
+            parts := strings.SplitN(key, "\x00", 2)
+            cells[parts[0]][tb.TableName] = append(cells[parts[0]][tb.TableName], fmt.Sprintf("%v(%v)", parts[1], strings.Join(columnPrivileges[key], ",")))
+        }
+    }
+
+    var roles []string
+    for role := range roleNames {
+        roles = append(roles, role)
+    }
+    sort.Strings(roles)
+
+    records := [][]string{header}
+    for _, role := range roles {
+        record := []string{role}
+        for _, tb := range templateValues.TableList {
+            record = append(record, strings.Join(cells[role][tb.TableName], ";"))
+        }
+        records = append(records, record)
+    }
+
+    csvErr := w.WriteAll(records)
+    if csvErr != nil {
+        err := &pkg.Error{Err: csvErr}
+        err.LogError()
+        return "", err
+    }
+
+    return csvData.String(), nil
+}
+
+// generateRegister prepares the csv register of the columns that have been classified as pii or sensitive.
+func (eng *Engine) generateRegister(templateValues domain.TemplateValues) (string, *pkg.Error) {
+    var csvData bytes.Buffer
+    w := csv.NewWriter(&csvData)
+
+    records := [][]string{
+        {"database", "table", "column", "data_type", "sensitivity", "tags", "comment"},
+    }
+    for _, tb := range templateValues.TableList {
+        for _, col := range tb.ColumnList {
+            if col.Sensitivity == "" {
+                continue
+            }
+
+            records = append(records, []string{
+                templateValues.DatabaseName,
+                tb.TableName,
+                col.Name,
+                col.DataType,
+                col.Sensitivity,
+                strings.Join(col.Tags, ";"),
+                col.Comment,
+            })
+        }
+    }
+
+    csvErr := w.WriteAll(records)
+    if csvErr != nil {
+        err := &pkg.Error{Err: csvErr}
+        err.LogError()
+        return "", err
+    }
+
+    return csvData.String(), nil
+}
@@ -0,0 +1,65 @@
+package pkg
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+)
+
+const regexPatternPrefix = "re:"
+
+// Patterns describes a list of name patterns. Each pattern is either a case insensitive glob (supporting '*' and '?')
+// or a regular expression when prefixed with 're:'.
+type Patterns []*regexp.Regexp
+
+// NewPatterns compiles and returns the provided glob or regular expression patterns.
+func NewPatterns(expressions []string) (Patterns, *Error) {
+    var patterns Patterns
+    for _, expr := range expressions {
+        var regexExpr string
+        if strings.HasPrefix(expr, regexPatternPrefix) {
+            regexExpr = strings.TrimPrefix(expr, regexPatternPrefix)
+        } else {
+            regexExpr = globToRegex(expr)
+        }
+
+        re, compileErr := regexp.Compile(regexExpr)
+        if compileErr != nil {
+            return nil, &Error{Err: fmt.Errorf("invalid pattern '%v' provided: %v", expr, compileErr)}
+        }
+
+        patterns = append(patterns, re)
+    }
+
+    return patterns, nil
+}
+
+// Match checks whether the value matches any of the patterns.
+func (p Patterns) Match(value string) bool {
+    for _, re := range p {
+        if re.MatchString(value) {
+            return true
+        }
+    }
+
+    return false
+}
+
+// globToRegex converts a glob pattern to the respective anchored and case insensitive regular expression.
+func globToRegex(glob string) string {
+    var sb strings.Builder
+    sb.WriteString("(?i)^")
+    for _, ch := range glob {
+        switch ch {
+        case '*':
+            sb.WriteString(".*")
+        case '?':
+            sb.WriteString(".")
+        default:
+            sb.WriteString(regexp.QuoteMeta(string(ch)))
+        }
+    }
+    sb.WriteString("$")
+
+    return sb.String()
+}
@@ -0,0 +1,89 @@
+package pkg
+
+import (
+    "testing"
+)
+
+func TestPatternsMatch(t *testing.T) {
+    testCases := map[string]struct {
+        expressions []string
+        value       string
+        expected    bool
+    }{
+        "exact glob matches": {
+            expressions: []string{"users"},
+            value:       "users",
+            expected:    true,
+        },
+        "glob is case insensitive": {
+            expressions: []string{"*Email*"},
+            value:       "CONTACT_EMAIL_ADDRESS",
+            expected:    true,
+        },
+        "glob is anchored": {
+            expressions: []string{"user"},
+            value:       "users",
+            expected:    false,
+        },
+        "question mark matches a single character": {
+            expressions: []string{"tmp_?"},
+            value:       "tmp_1",
+            expected:    true,
+        },
+        "question mark does not match multiple characters": {
+            expressions: []string{"tmp_?"},
+            value:       "tmp_12",
+            expected:    false,
+        },
+        "glob quotes the regular expression characters": {
+            expressions: []string{"users.email"},
+            value:       "usersXemail",
+            expected:    false,
+        },
+        "glob matches table.column names": {
+            expressions: []string{"users.*"},
+            value:       "users.email",
+            expected:    true,
+        },
+        "regular expression is not anchored": {
+            expressions: []string{"re:_p[0-9]{4}_[0-9]{2}$"},
+            value:       "events_p2020_01",
+            expected:    true,
+        },
+        "regular expression is case sensitive": {
+            expressions: []string{"re:^Users$"},
+            value:       "users",
+            expected:    false,
+        },
+        "any of the patterns matches": {
+            expressions: []string{"orders", "re:^pay"},
+            value:       "payments",
+            expected:    true,
+        },
+        "no patterns never match": {
+            expressions: nil,
+            value:       "users",
+            expected:    false,
+        },
+    }
+
+    for name, tc := range testCases {
+        t.Run(name, func(t *testing.T) {
+            patterns, err := NewPatterns(tc.expressions)
+            if err != nil {
+                t.Fatalf("unexpected error: %v", err.Err)
+            }
+
+            if actual := patterns.Match(tc.value); actual != tc.expected {
+                t.Errorf("expected match of '%v' against %v to be %v, got %v", tc.value, tc.expressions, tc.expected, actual)
+            }
+        })
+    }
+}
+
+func TestNewPatternsInvalidRegularExpression(t *testing.T) {
+    _, err := NewPatterns([]string{"users", "re:(unclosed"})
+    if err == nil {
+        t.Fatal("expected an error for the invalid regular expression")
+    }
+}